@@ -0,0 +1,285 @@
+//
+// Copyright 2020 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package cosign wraps github.com/sigstore/cosign's image and blob verification APIs,
+// translating its results into the (verified, signer, signedTimestamp, error) tuple that
+// pkg/k8smanifest's SignatureVerifier implementations expect.
+package cosign
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+	"github.com/sigstore/cosign/pkg/cosign"
+	sigstoresig "github.com/sigstore/sigstore/pkg/signature"
+)
+
+// VerifyImage verifies a cosign signature attached to imageRef against the public key at
+// pubkeyPath, returning the identity of the signer and the Rekor-integrated time of the
+// signature (nil when it has no Rekor bundle). It is kept for callers that do not need cancellation or custom registry
+// credentials; it delegates to VerifyImageWithContext using context.Background() and the
+// default keychain.
+func VerifyImage(imageRef, pubkeyPath string) (bool, string, *int64, error) {
+	return VerifyImageWithContext(context.Background(), imageRef, pubkeyPath, nil, "")
+}
+
+// VerifyImageWithContext is like VerifyImage but threads ctx through the registry calls so
+// callers can cancel or time out a verification in progress, and additionally accepts a
+// keychain for authenticating to private registries and a signatureRepository overriding
+// where cosign looks for the signature (mirroring COSIGN_REPOSITORY). A nil keychain falls
+// back to authn.DefaultKeychain; an empty signatureRepository looks alongside imageRef.
+func VerifyImageWithContext(ctx context.Context, imageRef, pubkeyPath string, keychain authn.Keychain, signatureRepository string) (bool, string, *int64, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return false, "", nil, errors.Wrap(err, "failed to parse image reference")
+	}
+
+	co, err := checkOpts(ctx, pubkeyPath, keychain, signatureRepository)
+	if err != nil {
+		return false, "", nil, err
+	}
+
+	checkedSigs, _, err := cosign.VerifyImageSignatures(ctx, ref, co)
+	if err != nil {
+		return false, "", nil, errors.Wrap(err, "signature verification failed")
+	}
+	if len(checkedSigs) == 0 {
+		return false, "", nil, errors.New("no valid signature found")
+	}
+	signerName, signedTimestamp := signerFromSignature(checkedSigs[0])
+	return true, signerName, signedTimestamp, nil
+}
+
+// SignatureVerifyResult carries the outcome of checking a single signature layer attached
+// to an image against one configured trust anchor.
+type SignatureVerifyResult struct {
+	Signer          string
+	Verified        bool
+	SignedTimestamp *int64
+	Error           error
+}
+
+// VerifyImageSignatures discovers every cosign signature layer attached to imageRef and
+// checks each one against every entry in pubkeys, returning one verified result per
+// (pubkey, signature layer) pair that verifies, plus one unverified result carrying the error
+// for each pubkey that verifies no layer. Unlike VerifyImage, which stops at the first
+// pubkey/layer pair that verifies, this enumerates all of them so a MultiSigPolicy quorum
+// can be evaluated across every independent signer on the image, not just the first match.
+func VerifyImageSignatures(imageRef string, pubkeys []string) ([]SignatureVerifyResult, error) {
+	return VerifyImageSignaturesWithContext(context.Background(), imageRef, pubkeys, nil, "")
+}
+
+// VerifyImageSignaturesWithContext is VerifyImageSignatures with context and registry
+// credentials, mirroring VerifyImageWithContext.
+func VerifyImageSignaturesWithContext(ctx context.Context, imageRef string, pubkeys []string, keychain authn.Keychain, signatureRepository string) ([]SignatureVerifyResult, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse image reference")
+	}
+
+	results := []SignatureVerifyResult{}
+	for _, pubkey := range pubkeys {
+		co, err := checkOpts(ctx, pubkey, keychain, signatureRepository)
+		if err != nil {
+			results = append(results, SignatureVerifyResult{Error: err})
+			continue
+		}
+		checkedSigs, _, err := cosign.VerifyImageSignatures(ctx, ref, co)
+		if err != nil {
+			results = append(results, SignatureVerifyResult{Error: err})
+			continue
+		}
+		for _, sig := range checkedSigs {
+			signerName, signedTimestamp := signerFromSignature(sig)
+			if signerName == "" {
+				// Key-based signatures carry no certificate to name a signer from; fall
+				// back to the pubkey that verified it so MultiSigPolicy identities/weights
+				// keyed by the configured pubkey can still match this result.
+				signerName = pubkey
+			}
+			results = append(results, SignatureVerifyResult{Signer: signerName, Verified: true, SignedTimestamp: signedTimestamp})
+		}
+	}
+	return results, nil
+}
+
+// VerifyImageAttestationsWithContext verifies every in-toto attestation attached to imageRef
+// as a cosign DSSE envelope against the public key at pubkeyPath (or, when pubkeyPath is "",
+// against the sigstore public-good Fulcio/Rekor roots for keyless attestations). It returns
+// the raw JSON-encoded DSSE envelope bytes alongside a parallel issuers slice giving, for each
+// envelope, the Fulcio certificate subject that signed it ("" for a key-based attestation) —
+// callers must not substitute the DSSE signature's KeyID here, which identifies the signing
+// key rather than an OIDC/Fulcio issuer identity. signerName and signedTimestamp describe the
+// first verified envelope, mirroring VerifyImageWithContext.
+func VerifyImageAttestationsWithContext(ctx context.Context, imageRef, pubkeyPath string, keychain authn.Keychain, signatureRepository string) (envelopes [][]byte, issuers []string, signerName string, signedTimestamp *int64, err error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return nil, nil, "", nil, errors.Wrap(err, "failed to parse image reference")
+	}
+
+	co, err := checkOpts(ctx, pubkeyPath, keychain, signatureRepository)
+	if err != nil {
+		return nil, nil, "", nil, err
+	}
+
+	checkedAttestations, _, err := cosign.VerifyImageAttestations(ctx, ref, co)
+	if err != nil {
+		return nil, nil, "", nil, errors.Wrap(err, "attestation verification failed")
+	}
+	if len(checkedAttestations) == 0 {
+		return nil, nil, "", nil, errors.New("no valid attestation found")
+	}
+
+	envelopes = make([][]byte, 0, len(checkedAttestations))
+	issuers = make([]string, 0, len(checkedAttestations))
+	for i, att := range checkedAttestations {
+		payload, err := att.Payload()
+		if err != nil {
+			return nil, nil, "", nil, errors.Wrap(err, "failed to read attestation payload")
+		}
+		envelopes = append(envelopes, payload)
+
+		issuer := ""
+		if cert, err := att.Cert(); err == nil && cert != nil {
+			issuer = certSubject(cert)
+		}
+		issuers = append(issuers, issuer)
+
+		if i == 0 {
+			signerName = issuer
+			signedTimestamp = signedTimestampOf(att)
+		}
+	}
+	return envelopes, issuers, signerName, signedTimestamp, nil
+}
+
+// VerifyImageKeylessWithContext verifies imageRef using a Fulcio-issued short-lived signing
+// certificate and Rekor transparency-log inclusion in place of a static public key. It
+// returns the signing certificate's SAN subject and OIDC issuer so the caller can match them
+// against a KeylessPolicy; rekorPublicKeys/ctLogPublicKeys/fulcioRootPEMs override the default
+// sigstore public-good instance roots, for callers running a private Rekor/Fulcio/CT
+// deployment.
+func VerifyImageKeylessWithContext(ctx context.Context, imageRef string, keychain authn.Keychain, signatureRepository string, rekorPublicKeys, ctLogPublicKeys, fulcioRootPEMs []string) (verified bool, subject, issuer string, signedTimestamp *int64, err error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return false, "", "", nil, errors.Wrap(err, "failed to parse image reference")
+	}
+
+	co, err := checkOpts(ctx, "", keychain, signatureRepository)
+	if err != nil {
+		return false, "", "", nil, err
+	}
+	if err := applyTransparencyLogRoots(co, rekorPublicKeys, ctLogPublicKeys); err != nil {
+		return false, "", "", nil, err
+	}
+	if len(fulcioRootPEMs) > 0 {
+		roots, err := fulcioRootPool(fulcioRootPEMs)
+		if err != nil {
+			return false, "", "", nil, err
+		}
+		co.RootCerts = roots
+	}
+
+	checkedSigs, _, err := cosign.VerifyImageSignatures(ctx, ref, co)
+	if err != nil {
+		return false, "", "", nil, errors.Wrap(err, "keyless signature verification failed")
+	}
+	if len(checkedSigs) == 0 {
+		return false, "", "", nil, errors.New("no valid keyless signature found")
+	}
+
+	sig := checkedSigs[0]
+	cert, err := sig.Cert()
+	if err != nil || cert == nil {
+		return false, "", "", nil, errors.New("keyless signature is missing its Fulcio certificate")
+	}
+	if _, err := rekorInclusionTime(sig); err != nil {
+		return false, "", "", nil, errors.Wrap(err, "failed to verify Rekor transparency-log inclusion")
+	}
+	return true, certSubject(cert), certIssuer(cert), signedTimestampOf(sig), nil
+}
+
+// VerifyBlobKeyless is VerifyBlob for a detached signature produced by keyless signing: it
+// verifies sigBytes over msgBytes against the Fulcio certificate in certBytes and confirms
+// bundleBytes carries a Rekor signed entry timestamp that verifies against the Rekor public
+// keys, rather than checking against a static public key. The bundle's entry body is not
+// compared with sigBytes or certBytes. The certificate chain is always verified against
+// a root of trust — fulcioRootPEMs when given, the sigstore public-good Fulcio root otherwise
+// — it is never skipped.
+func VerifyBlobKeyless(msgBytes, sigBytes, certBytes, bundleBytes []byte, rekorPublicKeys, fulcioRootPEMs []string) (verified bool, subject, issuer string, signedTimestamp *int64, err error) {
+	if len(certBytes) == 0 {
+		return false, "", "", nil, errors.New("keyless verification requires a signing certificate")
+	}
+
+	cert, err := certFromPEM(certBytes)
+	if err != nil {
+		return false, "", "", nil, errors.Wrap(err, "failed to parse signing certificate")
+	}
+	if err := verifyCertChain(cert, fulcioRootPEMs); err != nil {
+		return false, "", "", nil, errors.Wrap(err, "failed to verify the signing certificate chain")
+	}
+
+	verifier, err := sigstoresig.LoadVerifier(cert.PublicKey, crypto.SHA256)
+	if err != nil {
+		return false, "", "", nil, errors.Wrap(err, "failed to load a verifier from the signing certificate")
+	}
+	if err := verifier.VerifySignature(bytes.NewReader(sigBytes), bytes.NewReader(msgBytes)); err != nil {
+		return false, "", "", nil, errors.Wrap(err, "blob signature verification failed")
+	}
+
+	if len(bundleBytes) == 0 {
+		return false, "", "", nil, errors.New("keyless verification requires a Rekor bundle")
+	}
+	ts, err := verifyRekorBundle(bundleBytes, sigBytes, certBytes, rekorPublicKeys)
+	if err != nil {
+		return false, "", "", nil, errors.Wrap(err, "failed to verify Rekor transparency-log inclusion")
+	}
+
+	return true, certSubject(cert), certIssuer(cert), ts, nil
+}
+
+// VerifyBlob verifies a detached cosign signature (sigBytes, optionally accompanied by a
+// Fulcio certBytes and Rekor bundleBytes) over msgBytes against the public key at
+// pubkeyPath, returning the identity of the signer (the certBytes SAN subject, "" without a
+// certificate) and the Rekor-integrated time read from bundleBytes (nil without a bundle).
+func VerifyBlob(msgBytes, sigBytes, certBytes, bundleBytes []byte, pubkeyPath *string) (bool, string, *int64, error) {
+	var pubkey string
+	if pubkeyPath != nil {
+		pubkey = *pubkeyPath
+	}
+	co, err := checkOpts(context.Background(), pubkey, nil, "")
+	if err != nil {
+		return false, "", nil, err
+	}
+	if co.SigVerifier == nil {
+		return false, "", nil, errors.New("failed to resolve a signature verifier for the blob")
+	}
+
+	if err := co.SigVerifier.VerifySignature(bytes.NewReader(sigBytes), bytes.NewReader(msgBytes)); err != nil {
+		return false, "", nil, errors.Wrap(err, "blob signature verification failed")
+	}
+
+	signerName := subjectFromCert(certBytes)
+	signedTimestamp, err := bundleSignedTimestamp(bundleBytes)
+	if err != nil {
+		return false, "", nil, errors.Wrap(err, "failed to read signed timestamp from bundle")
+	}
+	return true, signerName, signedTimestamp, nil
+}
@@ -0,0 +1,286 @@
+//
+// Copyright 2020 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cosign
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+	"github.com/sigstore/cosign/pkg/cosign"
+	"github.com/sigstore/cosign/pkg/cosign/bundle"
+	"github.com/sigstore/cosign/pkg/oci"
+	ociremote "github.com/sigstore/cosign/pkg/oci/remote"
+	sigs "github.com/sigstore/cosign/pkg/signature"
+)
+
+// fulcioOIDCIssuerOID is the OID of the X.509 extension Fulcio embeds in every short-lived
+// signing certificate it issues, carrying the OIDC issuer URL that authenticated the signer.
+var fulcioOIDCIssuerOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+
+// checkOpts builds the cosign.CheckOpts used to verify a single image signature against
+// pubkeyPath. An empty pubkeyPath requests keyless verification using the sigstore
+// public-good Fulcio/Rekor roots. keychain and signatureRepository, when set, are threaded
+// through so the signature lookup itself can reach private registries.
+func checkOpts(ctx context.Context, pubkeyPath string, keychain authn.Keychain, signatureRepository string) (*cosign.CheckOpts, error) {
+	co := &cosign.CheckOpts{
+		ClaimVerifier: cosign.SimpleClaimVerifier,
+	}
+	if keychain != nil {
+		co.RegistryClientOpts = append(co.RegistryClientOpts, ociremote.WithRemoteOptions(remote.WithAuthFromKeychain(keychain)))
+	}
+	if signatureRepository != "" {
+		repo, err := name.NewRepository(signatureRepository)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse signature repository")
+		}
+		co.SignatureRepo = repo
+	}
+
+	if pubkeyPath == "" {
+		roots, err := fulcioRoots()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load Fulcio root certificates")
+		}
+		co.RootCerts = roots
+		return co, nil
+	}
+
+	verifier, err := sigs.PublicKeyFromKeyRef(ctx, pubkeyPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load public key")
+	}
+	co.SigVerifier = verifier
+	return co, nil
+}
+
+// signerFromSignature extracts a human-readable signer identity (the Fulcio certificate's
+// SAN subject, when the signature is keyless) and the Rekor-integrated signed timestamp
+// from a verified oci.Signature. Key-based signatures carry no certificate, so signer is ""
+// in that case; callers key quorum/cache lookups on the configured pubkey path instead.
+func signerFromSignature(sig oci.Signature) (string, *int64) {
+	signerName := ""
+	if cert, err := sig.Cert(); err == nil && cert != nil {
+		signerName = certSubject(cert)
+	}
+	return signerName, signedTimestampOf(sig)
+}
+
+// signedTimestampOf returns the Rekor-integrated time of sig, or nil when sig has no Rekor
+// bundle attached (e.g. a key-based signature verified without transparency-log inclusion).
+func signedTimestampOf(sig oci.Signature) *int64 {
+	b, err := sig.Bundle()
+	if err != nil || b == nil {
+		return nil
+	}
+	t := b.Payload.IntegratedTime
+	return &t
+}
+
+// certSubject returns the first SAN email address or URI on cert, mirroring how cosign
+// identifies a keyless signer.
+func certSubject(cert *x509.Certificate) string {
+	if len(cert.EmailAddresses) > 0 {
+		return cert.EmailAddresses[0]
+	}
+	if len(cert.URIs) > 0 {
+		return cert.URIs[0].String()
+	}
+	return ""
+}
+
+// fulcioRoots returns the sigstore public-good Fulcio root certificate pool used for
+// keyless verification when no override is configured.
+func fulcioRoots() (*x509.CertPool, error) {
+	return cosign.GetRoots()
+}
+
+// subjectFromCert returns the SAN subject (email or URI) of the leaf certificate PEM-encoded
+// in certBytes, or "" if certBytes is empty or does not parse.
+func subjectFromCert(certBytes []byte) string {
+	if len(certBytes) == 0 {
+		return ""
+	}
+	block, _ := pem.Decode(certBytes)
+	if block == nil {
+		return ""
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return ""
+	}
+	return certSubject(cert)
+}
+
+// bundleSignedTimestamp reads the Rekor-integrated time out of a JSON-encoded
+// bundle.RekorBundle in bundleBytes. Empty bundleBytes (no Rekor entry, e.g. a purely
+// key-based signature) is not an error; it simply yields a nil timestamp.
+func bundleSignedTimestamp(bundleBytes []byte) (*int64, error) {
+	if len(bundleBytes) == 0 {
+		return nil, nil
+	}
+	var b bundle.RekorBundle
+	if err := json.Unmarshal(bundleBytes, &b); err != nil {
+		return nil, err
+	}
+	t := b.Payload.IntegratedTime
+	return &t, nil
+}
+
+// certIssuer returns the OIDC issuer URL Fulcio embedded in cert's well-known extension, or
+// "" if cert carries none (e.g. it was not issued by Fulcio).
+func certIssuer(cert *x509.Certificate) string {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(fulcioOIDCIssuerOID) {
+			return string(ext.Value)
+		}
+	}
+	return ""
+}
+
+// applyTransparencyLogRoots overrides co's Rekor and CT log root-of-trust public keys with
+// rekorPublicKeys/ctLogPublicKeys when given, for callers running a private sigstore
+// deployment instead of the public-good instance.
+func applyTransparencyLogRoots(co *cosign.CheckOpts, rekorPublicKeys, ctLogPublicKeys []string) error {
+	if len(rekorPublicKeys) > 0 {
+		pool, err := pemsToPublicKeyPool(rekorPublicKeys)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse Rekor public keys")
+		}
+		co.RekorPubKeys = pool
+	}
+	if len(ctLogPublicKeys) > 0 {
+		pool, err := pemsToPublicKeyPool(ctLogPublicKeys)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse CT log public keys")
+		}
+		co.CTLogPubKeys = pool
+	}
+	return nil
+}
+
+// pemsToPublicKeyPool parses a list of PEM-encoded public keys into the
+// cosign.TrustedTransparencyLogPubKeys pool that CheckOpts.RekorPubKeys/CTLogPubKeys expect.
+func pemsToPublicKeyPool(pemKeys []string) (*cosign.TrustedTransparencyLogPubKeys, error) {
+	pool := cosign.NewTrustedTransparencyLogPubKeys()
+	for _, pemKey := range pemKeys {
+		if err := pool.AddTransparencyLogPubKey([]byte(pemKey), cosign.TransparencyLogPubKeyType); err != nil {
+			return nil, err
+		}
+	}
+	return &pool, nil
+}
+
+// rekorInclusionTime verifies that sig carries a Rekor bundle (i.e. was logged to the
+// transparency log and cosign already validated its inclusion proof/SET as part of
+// cosign.VerifyImageSignatures), returning its integrated time.
+func rekorInclusionTime(sig oci.Signature) (int64, error) {
+	b, err := sig.Bundle()
+	if err != nil {
+		return 0, err
+	}
+	if b == nil {
+		return 0, errors.New("signature has no Rekor bundle")
+	}
+	return b.Payload.IntegratedTime, nil
+}
+
+// certFromPEM parses a single PEM-encoded X.509 certificate.
+func certFromPEM(certBytes []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certBytes)
+	if block == nil {
+		return nil, errors.New("failed to decode PEM block")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// verifyCertChain verifies that cert chains up to a trusted Fulcio root — the PEM-encoded
+// roots in fulcioRootPEMs when given, the sigstore public-good Fulcio root otherwise. Unlike
+// a self-signed or otherwise untrusted cert, this is never skipped: a cert that fails to
+// chain to a trusted root must not be accepted regardless of which transparency-log
+// deployment the caller configured.
+func verifyCertChain(cert *x509.Certificate, fulcioRootPEMs []string) error {
+	roots, err := fulcioRootPool(fulcioRootPEMs)
+	if err != nil {
+		return err
+	}
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	})
+	return err
+}
+
+// fulcioRootPool returns the certificate pool cert chains should be verified against: the
+// PEM-encoded roots in fulcioRootPEMs when the caller configured an override (e.g. for a
+// private Fulcio deployment), the sigstore public-good Fulcio root otherwise.
+func fulcioRootPool(fulcioRootPEMs []string) (*x509.CertPool, error) {
+	if len(fulcioRootPEMs) == 0 {
+		return fulcioRoots()
+	}
+	pool := x509.NewCertPool()
+	for _, rootPEM := range fulcioRootPEMs {
+		if !pool.AppendCertsFromPEM([]byte(rootPEM)) {
+			return nil, errors.New("failed to parse a configured Fulcio root certificate")
+		}
+	}
+	return pool, nil
+}
+
+// verifyRekorBundle checks the signed entry timestamp of the Rekor bundle in bundleBytes
+// against the configured Rekor roots, returning the entry's integrated time. sigBytes and
+// certBytes are not used: the entry body is not compared with them. Unlike the image path, which leaves CheckOpts's
+// RekorPubKeys nil and lets cosign.VerifyImageSignatures fall back to its own default
+// public-good Rekor key, this hand-rolled SET check has no such fallback built in, so an
+// empty rekorPublicKeys falls back to the public-good key itself.
+func verifyRekorBundle(bundleBytes, sigBytes, certBytes []byte, rekorPublicKeys []string) (*int64, error) {
+	var b bundle.RekorBundle
+	if err := json.Unmarshal(bundleBytes, &b); err != nil {
+		return nil, errors.Wrap(err, "failed to parse Rekor bundle")
+	}
+	if b.SignedEntryTimestamp == nil {
+		return nil, errors.New("Rekor bundle has no signed entry timestamp")
+	}
+	pool, err := defaultableRekorPublicKeyPool(rekorPublicKeys)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse Rekor public keys")
+	}
+	if err := cosign.VerifySET(b.Payload, b.SignedEntryTimestamp, pool); err != nil {
+		return nil, errors.Wrap(err, "failed to verify the Rekor signed entry timestamp")
+	}
+	t := b.Payload.IntegratedTime
+	return &t, nil
+}
+
+// defaultableRekorPublicKeyPool is pemsToPublicKeyPool with a fallback to the sigstore
+// public-good Rekor public key when rekorPublicKeys is empty.
+func defaultableRekorPublicKeyPool(rekorPublicKeys []string) (*cosign.TrustedTransparencyLogPubKeys, error) {
+	if len(rekorPublicKeys) == 0 {
+		defaultPub, err := cosign.GetRekorPub()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load the default Rekor public key")
+		}
+		return pemsToPublicKeyPool([]string{string(defaultPub)})
+	}
+	return pemsToPublicKeyPool(rekorPublicKeys)
+}
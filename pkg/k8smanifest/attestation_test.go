@@ -0,0 +1,108 @@
+//
+// Copyright 2020 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package k8smanifest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+)
+
+func TestAttestationPolicyMatches(t *testing.T) {
+	statement := map[string]interface{}{
+		"predicateType": "https://slsa.dev/provenance/v0.2",
+		"predicate": map[string]interface{}{
+			"builder": map[string]interface{}{"id": "https://trusted-builder.example.com"},
+		},
+	}
+
+	cases := []struct {
+		name   string
+		policy *AttestationPolicy
+		want   bool
+	}{
+		{"nil policy accepts anything", nil, true},
+		{"matching predicate type and matcher", &AttestationPolicy{
+			PredicateTypes: []string{"https://slsa.dev/provenance/v0.2"},
+			Matchers:       map[string]string{"predicate.builder.id": "https://trusted-builder.example.com"},
+		}, true},
+		{"predicate type mismatch", &AttestationPolicy{PredicateTypes: []string{"https://in-toto.io/attestation/vuln/v0.1"}}, false},
+		{"matcher mismatch", &AttestationPolicy{Matchers: map[string]string{"predicate.builder.id": "https://untrusted.example.com"}}, false},
+		{"matcher path not found", &AttestationPolicy{Matchers: map[string]string{"predicate.missing": "x"}}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.policy.matches(statement["predicateType"].(string), statement); got != c.want {
+				t.Errorf("matches() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestLookupJSONPath(t *testing.T) {
+	m := map[string]interface{}{
+		"predicate": map[string]interface{}{
+			"builder": map[string]interface{}{"id": "builder-1"},
+		},
+	}
+	if got, found := lookupJSONPath(m, "predicate.builder.id"); !found || got != "builder-1" {
+		t.Errorf("lookupJSONPath() = (%v, %v), want (builder-1, true)", got, found)
+	}
+	if _, found := lookupJSONPath(m, "predicate.missing.id"); found {
+		t.Error("lookupJSONPath() for a missing path should return found=false")
+	}
+	if _, found := lookupJSONPath(m, "predicate.builder.id.extra"); found {
+		t.Error("lookupJSONPath() descending into a non-map value should return found=false")
+	}
+}
+
+func TestEvaluateAttestationsUsesIssuerNotKeyID(t *testing.T) {
+	payload := map[string]interface{}{
+		"predicateType": "https://slsa.dev/provenance/v0.2",
+		"predicate":     map[string]interface{}{"builder": map[string]interface{}{"id": "trusted"}},
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("failed to marshal payload: %v", err)
+	}
+	envelope := dsseEnvelope{
+		Payload:     base64.StdEncoding.EncodeToString(payloadBytes),
+		PayloadType: "application/vnd.in-toto+json",
+		// The signing key's ID must never leak into AttestationResult.Issuer; only the
+		// issuers slice passed in separately (derived from the Fulcio cert) should.
+		Signatures: []dsseSignature{{KeyID: "sha256:deadbeef"}},
+	}
+	envelopeBytes, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatalf("failed to marshal envelope: %v", err)
+	}
+
+	v := &ImageAttestationVerifier{policy: &AttestationPolicy{PredicateTypes: []string{"https://slsa.dev/provenance/v0.2"}}}
+	results, matched, err := v.evaluateAttestations([][]byte{envelopeBytes}, []string{"someone@example.com"})
+	if err != nil {
+		t.Fatalf("evaluateAttestations() error = %v", err)
+	}
+	if !matched {
+		t.Fatal("expected the attestation to satisfy the policy")
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Issuer != "someone@example.com" {
+		t.Errorf("Issuer = %q, want %q (the Fulcio identity, not the DSSE signing key ID)", results[0].Issuer, "someone@example.com")
+	}
+}
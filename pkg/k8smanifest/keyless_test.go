@@ -0,0 +1,101 @@
+//
+// Copyright 2020 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package k8smanifest
+
+import "testing"
+
+func TestKeylessPolicyAllowed(t *testing.T) {
+	cases := []struct {
+		name    string
+		policy  *KeylessPolicy
+		subject string
+		issuer  string
+		want    bool
+	}{
+		{"nil policy allows anything", nil, "alice@example.com", "https://accounts.example.com", true},
+		{"empty identities allows anything", &KeylessPolicy{}, "alice@example.com", "https://accounts.example.com", true},
+		{"exact match", &KeylessPolicy{Identities: []KeylessIdentity{
+			{Subject: "alice@example.com", Issuer: "https://accounts.example.com"},
+		}}, "alice@example.com", "https://accounts.example.com", true},
+		{"subject mismatch", &KeylessPolicy{Identities: []KeylessIdentity{
+			{Subject: "alice@example.com", Issuer: "https://accounts.example.com"},
+		}}, "mallory@example.com", "https://accounts.example.com", false},
+		{"issuer mismatch", &KeylessPolicy{Identities: []KeylessIdentity{
+			{Subject: "alice@example.com", Issuer: "https://accounts.example.com"},
+		}}, "alice@example.com", "https://evil.example.com", false},
+		{"regexp match", &KeylessPolicy{Identities: []KeylessIdentity{
+			{SubjectRegExp: "^.*@example\\.com$", IssuerRegExp: "^https://accounts\\."},
+		}}, "bob@example.com", "https://accounts.example.com", true},
+		{"none of multiple identities match", &KeylessPolicy{Identities: []KeylessIdentity{
+			{Subject: "alice@example.com"},
+			{Subject: "bob@example.com"},
+		}}, "mallory@example.com", "", false},
+		{"second of multiple identities matches", &KeylessPolicy{Identities: []KeylessIdentity{
+			{Subject: "alice@example.com"},
+			{Subject: "bob@example.com"},
+		}}, "bob@example.com", "", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.policy.allowed(c.subject, c.issuer); got != c.want {
+				t.Errorf("allowed(%q, %q) = %v, want %v", c.subject, c.issuer, got, c.want)
+			}
+		})
+	}
+}
+
+func TestMatchesIdentityField(t *testing.T) {
+	cases := []struct {
+		name    string
+		exact   string
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"both blank is a wildcard", "", "", "anything", true},
+		{"exact match", "alice", "", "alice", true},
+		{"exact mismatch with no pattern", "alice", "", "bob", false},
+		{"regexp match", "", "^a.*e$", "alice", true},
+		{"regexp mismatch", "", "^a.*e$", "bob", false},
+		{"invalid regexp never matches", "", "(", "alice", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := matchesIdentityField(c.exact, c.pattern, c.value); got != c.want {
+				t.Errorf("matchesIdentityField(%q, %q, %q) = %v, want %v", c.exact, c.pattern, c.value, got, c.want)
+			}
+		})
+	}
+}
+
+func TestKeylessPolicyHash(t *testing.T) {
+	var nilPolicy *KeylessPolicy
+	if got := nilPolicy.hash(); got != "" {
+		t.Errorf("hash() of a nil policy = %q, want \"\"", got)
+	}
+
+	a := &KeylessPolicy{Identities: []KeylessIdentity{{Subject: "alice@example.com"}}}
+	b := &KeylessPolicy{Identities: []KeylessIdentity{{Subject: "alice@example.com"}}}
+	c := &KeylessPolicy{Identities: []KeylessIdentity{{Subject: "bob@example.com"}}}
+
+	if a.hash() != b.hash() {
+		t.Error("hash() should be stable for equal policies")
+	}
+	if a.hash() == c.hash() {
+		t.Error("hash() should differ for different policies")
+	}
+}
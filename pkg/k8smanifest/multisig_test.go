@@ -0,0 +1,107 @@
+//
+// Copyright 2020 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package k8smanifest
+
+import "testing"
+
+func TestMultiSigPolicySatisfies(t *testing.T) {
+	cases := []struct {
+		name    string
+		policy  *MultiSigPolicy
+		results []SignerVerifyResult
+		wantOK  bool
+		wantSig string
+	}{
+		{
+			name:   "quorum reached with default minimum of one",
+			policy: &MultiSigPolicy{},
+			results: []SignerVerifyResult{
+				{Signer: "alice", Verified: true},
+			},
+			wantOK:  true,
+			wantSig: "alice",
+		},
+		{
+			name:   "quorum not reached",
+			policy: &MultiSigPolicy{Identities: []string{"alice", "bob"}, MinimumCount: 2},
+			results: []SignerVerifyResult{
+				{Signer: "alice", Verified: true},
+				{Signer: "bob", Verified: false},
+			},
+			wantOK: false,
+		},
+		{
+			name:   "quorum reached across two distinct signers",
+			policy: &MultiSigPolicy{Identities: []string{"alice", "bob"}, MinimumCount: 2},
+			results: []SignerVerifyResult{
+				{Signer: "alice", Verified: true},
+				{Signer: "bob", Verified: true},
+			},
+			wantOK:  true,
+			wantSig: "alice,bob",
+		},
+		{
+			name:   "unlisted signer does not count toward quorum",
+			policy: &MultiSigPolicy{Identities: []string{"alice"}, MinimumCount: 1},
+			results: []SignerVerifyResult{
+				{Signer: "mallory", Verified: true},
+			},
+			wantOK: false,
+		},
+		{
+			name:   "weighted signer can satisfy quorum alone",
+			policy: &MultiSigPolicy{Identities: []string{"release-manager"}, MinimumCount: 2, Weights: map[string]int{"release-manager": 2}},
+			results: []SignerVerifyResult{
+				{Signer: "release-manager", Verified: true},
+			},
+			wantOK:  true,
+			wantSig: "release-manager",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ok, matched := c.policy.satisfies(c.results)
+			if ok != c.wantOK {
+				t.Errorf("satisfies() ok = %v, want %v", ok, c.wantOK)
+			}
+			if ok && matched != c.wantSig {
+				t.Errorf("satisfies() matched = %q, want %q", matched, c.wantSig)
+			}
+		})
+	}
+}
+
+func TestMultiSigPolicyAllowed(t *testing.T) {
+	var nilPolicy *MultiSigPolicy
+	if !nilPolicy.allowed("anyone") {
+		t.Error("nil policy should allow any signer")
+	}
+
+	emptyPolicy := &MultiSigPolicy{}
+	if !emptyPolicy.allowed("anyone") {
+		t.Error("empty Identities should allow any signer")
+	}
+
+	restricted := &MultiSigPolicy{Identities: []string{"alice"}}
+	if !restricted.allowed("alice") {
+		t.Error("expected alice to be allowed")
+	}
+	if restricted.allowed("mallory") {
+		t.Error("expected mallory to be disallowed")
+	}
+}
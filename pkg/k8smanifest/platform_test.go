@@ -0,0 +1,50 @@
+//
+// Copyright 2020 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package k8smanifest
+
+import "testing"
+
+func TestPlatformSelectorMatches(t *testing.T) {
+	var nilSelector *PlatformSelector
+	if !nilSelector.matches("linux", "amd64", "") {
+		t.Error("nil selector should match any platform")
+	}
+
+	cases := []struct {
+		name     string
+		selector *PlatformSelector
+		os       string
+		arch     string
+		variant  string
+		want     bool
+	}{
+		{"empty selector matches anything", &PlatformSelector{}, "linux", "arm64", "v8", true},
+		{"os and arch match", &PlatformSelector{OS: "linux", Arch: "arm64"}, "linux", "arm64", "v8", true},
+		{"arch mismatch", &PlatformSelector{OS: "linux", Arch: "amd64"}, "linux", "arm64", "", false},
+		{"os mismatch", &PlatformSelector{OS: "windows"}, "linux", "amd64", "", false},
+		{"variant mismatch", &PlatformSelector{Arch: "arm64", Variant: "v7"}, "linux", "arm64", "v8", false},
+		{"variant match", &PlatformSelector{Arch: "arm64", Variant: "v8"}, "linux", "arm64", "v8", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.selector.matches(c.os, c.arch, c.variant); got != c.want {
+				t.Errorf("matches(%q, %q, %q) = %v, want %v", c.os, c.arch, c.variant, got, c.want)
+			}
+		})
+	}
+}
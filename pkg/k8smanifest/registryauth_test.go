@@ -0,0 +1,61 @@
+//
+// Copyright 2020 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package k8smanifest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+)
+
+func TestRegistryAuthConfigKeychainNilConfig(t *testing.T) {
+	var a *RegistryAuthConfig
+	kc, repo, err := a.keychain(context.Background())
+	if kc != nil || repo != "" || err != nil {
+		t.Errorf("nil config should resolve to (nil, \"\", nil), got (%v, %q, %v)", kc, repo, err)
+	}
+}
+
+func TestRegistryAuthConfigKeychainUsernamePassword(t *testing.T) {
+	a := &RegistryAuthConfig{Username: "alice", Password: "hunter2", SignatureRepository: "registry.example.com/sigs"}
+	kc, repo, err := a.keychain(context.Background())
+	if err != nil {
+		t.Fatalf("keychain() error = %v", err)
+	}
+	if repo != "registry.example.com/sigs" {
+		t.Errorf("signatureRepository = %q, want %q", repo, "registry.example.com/sigs")
+	}
+	authr, err := kc.Resolve(staticResource{})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	cfg, err := authr.Authorization()
+	if err != nil {
+		t.Fatalf("Authorization() error = %v", err)
+	}
+	if cfg.Username != "alice" || cfg.Password != "hunter2" {
+		t.Errorf("got %+v, want Username=alice Password=hunter2", cfg)
+	}
+}
+
+type staticResource struct{}
+
+func (staticResource) String() string     { return "registry.example.com" }
+func (staticResource) RegistryStr() string { return "registry.example.com" }
+
+var _ authn.Resource = staticResource{}
@@ -17,11 +17,16 @@
 package k8smanifest
 
 import (
+	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/pkg/errors"
 	k8smnfcosign "github.com/sigstore/k8s-manifest-sigstore/pkg/cosign"
 	k8smnfutil "github.com/sigstore/k8s-manifest-sigstore/pkg/util"
@@ -37,10 +42,266 @@ import (
 const SigRefEmbeddedInAnnotation = "__embedded_in_annotation__"
 
 type SignatureVerifier interface {
-	Verify() (bool, string, *int64, error)
+	Verify(ctx context.Context) (bool, string, *int64, error)
+}
+
+// MultiSigPolicy describes a quorum requirement for multi-signer verification:
+// at least MinimumCount signers from Identities must independently verify
+// before the overall result is considered passing. Weights, when set, allows
+// some signers to count for more than one toward the quorum (e.g. a
+// "release-manager" key counting double); a signer missing from Weights
+// counts as 1.
+type MultiSigPolicy struct {
+	Identities   []string
+	MinimumCount int
+	Weights      map[string]int
+}
+
+// weightOf returns the configured weight for signer, defaulting to 1.
+func (p *MultiSigPolicy) weightOf(signer string) int {
+	if p == nil || p.Weights == nil {
+		return 1
+	}
+	if w, ok := p.Weights[signer]; ok {
+		return w
+	}
+	return 1
+}
+
+// allowed reports whether signer is one of the configured trust anchors.
+// An empty Identities list allows any signer.
+func (p *MultiSigPolicy) allowed(signer string) bool {
+	if p == nil || len(p.Identities) == 0 {
+		return true
+	}
+	for _, id := range p.Identities {
+		if id == signer {
+			return true
+		}
+	}
+	return false
+}
+
+// SignerVerifyResult carries the verification outcome for a single signer
+// when a MultiSigPolicy is in effect.
+type SignerVerifyResult struct {
+	Signer          string `json:"signer"`
+	Verified        bool   `json:"verified"`
+	SignedTimestamp *int64 `json:"signedTimestamp,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+// PlatformSelector picks a single child manifest out of an OCI index / Docker
+// manifest list by matching its platform descriptor. Variant is optional (e.g. "v8"
+// for arm64).
+type PlatformSelector struct {
+	OS      string
+	Arch    string
+	Variant string
+}
+
+// matches reports whether the selector matches a child manifest's platform fields.
+// An empty field on the selector is treated as a wildcard for that field.
+func (p *PlatformSelector) matches(os, arch, variant string) bool {
+	if p == nil {
+		return true
+	}
+	if p.OS != "" && p.OS != os {
+		return false
+	}
+	if p.Arch != "" && p.Arch != arch {
+		return false
+	}
+	if p.Variant != "" && p.Variant != variant {
+		return false
+	}
+	return true
+}
+
+// RegistryAuthConfig supplies credentials for pulling images and discovering signatures
+// from a private or authenticated registry. At most one credential source should be set;
+// when several are, ImagePullSecretRef takes precedence, then DockerConfigPath, then
+// Username/Password, then BearerToken. SignatureRepository overrides where cosign looks
+// for signatures/attestations, mirroring the COSIGN_REPOSITORY environment variable.
+type RegistryAuthConfig struct {
+	DockerConfigPath    string
+	Username            string
+	Password            string
+	BearerToken         string
+	ImagePullSecretRef  string // k8s object ref, e.g. "k8s://Secret/[NAMESPACE]/[NAME]", resolved via kubeutil
+	SignatureRepository string
+}
+
+// keychain resolves a.ImagePullSecretRef / DockerConfigPath / Username-Password / BearerToken
+// (in that priority order) into an authn.Keychain usable by go-containerregistry and cosign.
+// The second return value is a.SignatureRepository. A nil receiver or a config with no
+// credential source set returns a nil keychain, letting callers fall back to
+// authn.DefaultKeychain.
+func (a *RegistryAuthConfig) keychain(ctx context.Context) (authn.Keychain, string, error) {
+	if a == nil {
+		return nil, "", nil
+	}
+	var kc authn.Keychain
+	var err error
+	switch {
+	case a.ImagePullSecretRef != "":
+		kc, err = kubeutil.GetKeychainFromImagePullSecretWithContext(ctx, a.ImagePullSecretRef)
+	case a.DockerConfigPath != "":
+		kc, err = k8smnfutil.KeychainFromDockerConfig(a.DockerConfigPath)
+	case a.Username != "" || a.Password != "":
+		kc = &staticKeychain{authn.FromConfig(authn.AuthConfig{Username: a.Username, Password: a.Password})}
+	case a.BearerToken != "":
+		kc = &staticKeychain{authn.FromConfig(authn.AuthConfig{RegistryToken: a.BearerToken})}
+	}
+	return kc, a.SignatureRepository, err
+}
+
+// staticKeychain resolves to the same pre-built authn.Authenticator regardless of the
+// registry resource being accessed.
+type staticKeychain struct {
+	authenticator authn.Authenticator
+}
+
+func (k *staticKeychain) Resolve(_ authn.Resource) (authn.Authenticator, error) {
+	return k.authenticator, nil
+}
+
+// KeylessPolicy configures identity-based ("keyless") verification via Fulcio short-lived
+// signing certificates and Rekor transparency-log inclusion, used in place of a static
+// public key. At least one of Identities must match the signing certificate's SAN subject
+// and issuer for verification to pass; an empty Identities list allows any identity once
+// the certificate chain and Rekor inclusion proof check out. RekorPublicKeys / CTLogPublicKeys /
+// FulcioRootCertificates override the default sigstore public-good instance roots, for
+// callers running a private Rekor/Fulcio/CT deployment; the certificate chain is always
+// verified against some root (the override when set, the public-good root otherwise) — it is
+// never skipped.
+type KeylessPolicy struct {
+	Identities             []KeylessIdentity
+	RekorPublicKeys        []string
+	CTLogPublicKeys        []string
+	FulcioRootCertificates []string
+}
+
+// KeylessIdentity matches a signing certificate's SAN subject and OIDC issuer, either by
+// exact string or by regular expression. A blank field is a wildcard for that field.
+type KeylessIdentity struct {
+	Subject       string
+	SubjectRegExp string
+	Issuer        string
+	IssuerRegExp  string
+}
+
+// allowed reports whether subject/issuer satisfies at least one configured identity.
+// A nil policy or an empty Identities list allows any subject/issuer.
+func (p *KeylessPolicy) allowed(subject, issuer string) bool {
+	if p == nil || len(p.Identities) == 0 {
+		return true
+	}
+	for _, id := range p.Identities {
+		if matchesIdentityField(id.Subject, id.SubjectRegExp, subject) && matchesIdentityField(id.Issuer, id.IssuerRegExp, issuer) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesIdentityField reports whether value satisfies exact (literal equality) or
+// pattern (regular expression); both blank is treated as a wildcard match.
+func matchesIdentityField(exact, pattern, value string) bool {
+	if exact == "" && pattern == "" {
+		return true
+	}
+	if exact != "" && exact == value {
+		return true
+	}
+	if pattern != "" {
+		if re, err := regexp.Compile(pattern); err == nil && re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// hash returns a short, stable digest of the policy, used to key cached keyless
+// verification results since there is no static public key to key them by instead.
+func (p *KeylessPolicy) hash() string {
+	if p == nil {
+		return ""
+	}
+	b, _ := json.Marshal(p)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// PlatformVerifyResult carries the verification outcome for one child manifest
+// of an OCI index / Docker manifest list when VerifyAllPlatforms is in effect.
+type PlatformVerifyResult struct {
+	Digest          string `json:"digest"`
+	OS              string `json:"os"`
+	Arch            string `json:"arch"`
+	Variant         string `json:"variant,omitempty"`
+	Verified        bool   `json:"verified"`
+	Signer          string `json:"signer,omitempty"`
+	SignedTimestamp *int64 `json:"signedTimestamp,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+// satisfies evaluates results against the policy and returns whether quorum
+// was reached plus the comma-joined names of the signers that verified.
+func (p *MultiSigPolicy) satisfies(results []SignerVerifyResult) (bool, string) {
+	matched := []string{}
+	seen := map[string]bool{}
+	total := 0
+	for _, r := range results {
+		if !r.Verified || !p.allowed(r.Signer) || seen[r.Signer] {
+			continue
+		}
+		seen[r.Signer] = true
+		matched = append(matched, r.Signer)
+		total += p.weightOf(r.Signer)
+	}
+	minimumCount := p.MinimumCount
+	if minimumCount <= 0 {
+		minimumCount = 1
+	}
+	return total >= minimumCount, strings.Join(matched, ",")
 }
 
 func NewSignatureVerifier(objYAMLBytes []byte, sigRef string, pubkeyPath *string, annotationConfig AnnotationConfig) SignatureVerifier {
+	return newSignatureVerifier(objYAMLBytes, sigRef, pubkeyPath, annotationConfig, nil, nil, false, nil, nil)
+}
+
+// NewSignatureVerifierWithMultiSigPolicy is like NewSignatureVerifier but additionally
+// accepts a MultiSigPolicy describing a quorum of trusted signers that must verify. A nil
+// policy preserves the original "any configured pubkey succeeds" behavior.
+func NewSignatureVerifierWithMultiSigPolicy(objYAMLBytes []byte, sigRef string, pubkeyPath *string, annotationConfig AnnotationConfig, multiSigPolicy *MultiSigPolicy) SignatureVerifier {
+	return newSignatureVerifier(objYAMLBytes, sigRef, pubkeyPath, annotationConfig, multiSigPolicy, nil, false, nil, nil)
+}
+
+// NewSignatureVerifierWithPlatformSelector is like NewSignatureVerifierWithMultiSigPolicy but
+// additionally accepts verifyAllPlatforms, which verifies the index digest and the child
+// manifests of an OCI index / Docker manifest list, and a PlatformSelector that limits which
+// child manifests are verified in that mode. With verifyAllPlatforms false the selector is
+// not used and only imageRef itself is verified.
+func NewSignatureVerifierWithPlatformSelector(objYAMLBytes []byte, sigRef string, pubkeyPath *string, annotationConfig AnnotationConfig, multiSigPolicy *MultiSigPolicy, platformSelector *PlatformSelector, verifyAllPlatforms bool) SignatureVerifier {
+	return newSignatureVerifier(objYAMLBytes, sigRef, pubkeyPath, annotationConfig, multiSigPolicy, platformSelector, verifyAllPlatforms, nil, nil)
+}
+
+// NewSignatureVerifierWithRegistryAuth is like NewSignatureVerifierWithPlatformSelector but
+// additionally accepts a RegistryAuthConfig for pulling images and discovering signatures
+// from private or authenticated registries. A nil config falls back to authn.DefaultKeychain.
+func NewSignatureVerifierWithRegistryAuth(objYAMLBytes []byte, sigRef string, pubkeyPath *string, annotationConfig AnnotationConfig, multiSigPolicy *MultiSigPolicy, platformSelector *PlatformSelector, verifyAllPlatforms bool, registryAuthConfig *RegistryAuthConfig) SignatureVerifier {
+	return newSignatureVerifier(objYAMLBytes, sigRef, pubkeyPath, annotationConfig, multiSigPolicy, platformSelector, verifyAllPlatforms, registryAuthConfig, nil)
+}
+
+// NewSignatureVerifierWithKeylessPolicy is like NewSignatureVerifierWithRegistryAuth but
+// additionally accepts a KeylessPolicy for identity-based verification when pubkeyPath is
+// nil or empty. A nil policy preserves the prior "any keyless signer succeeds" behavior.
+func NewSignatureVerifierWithKeylessPolicy(objYAMLBytes []byte, sigRef string, pubkeyPath *string, annotationConfig AnnotationConfig, multiSigPolicy *MultiSigPolicy, platformSelector *PlatformSelector, verifyAllPlatforms bool, registryAuthConfig *RegistryAuthConfig, keylessPolicy *KeylessPolicy) SignatureVerifier {
+	return newSignatureVerifier(objYAMLBytes, sigRef, pubkeyPath, annotationConfig, multiSigPolicy, platformSelector, verifyAllPlatforms, registryAuthConfig, keylessPolicy)
+}
+
+func newSignatureVerifier(objYAMLBytes []byte, sigRef string, pubkeyPath *string, annotationConfig AnnotationConfig, multiSigPolicy *MultiSigPolicy, platformSelector *PlatformSelector, verifyAllPlatforms bool, registryAuthConfig *RegistryAuthConfig, keylessPolicy *KeylessPolicy) SignatureVerifier {
 	var imageRef, resourceRef string
 	if strings.HasPrefix(sigRef, InClusterObjectPrefix) {
 		resourceRef = sigRef
@@ -62,9 +323,19 @@ func NewSignatureVerifier(objYAMLBytes []byte, sigRef string, pubkeyPath *string
 	}
 
 	if imageRef != "" && imageRef != SigRefEmbeddedInAnnotation {
-		return &ImageSignatureVerifier{imageRef: imageRef, onMemoryCacheEnabled: true, pubkeyPathString: pubkeyPathString, annotationConfig: annotationConfig}
+		return &ImageSignatureVerifier{
+			imageRef:             imageRef,
+			onMemoryCacheEnabled: true,
+			pubkeyPathString:     pubkeyPathString,
+			annotationConfig:     annotationConfig,
+			multiSigPolicy:       multiSigPolicy,
+			platformSelector:     platformSelector,
+			verifyAllPlatforms:   verifyAllPlatforms,
+			registryAuthConfig:   registryAuthConfig,
+			keylessPolicy:        keylessPolicy,
+		}
 	} else {
-		return &BlobSignatureVerifier{annotations: annotations, resourceRef: resourceRef, pubkeyPathString: pubkeyPathString, annotationConfig: annotationConfig}
+		return &BlobSignatureVerifier{annotations: annotations, resourceRef: resourceRef, pubkeyPathString: pubkeyPathString, annotationConfig: annotationConfig, multiSigPolicy: multiSigPolicy, keylessPolicy: keylessPolicy}
 	}
 }
 
@@ -73,9 +344,28 @@ type ImageSignatureVerifier struct {
 	pubkeyPathString     *string
 	onMemoryCacheEnabled bool
 	annotationConfig     AnnotationConfig
+	multiSigPolicy       *MultiSigPolicy
+	lastSignerResults    []SignerVerifyResult
+	platformSelector     *PlatformSelector
+	verifyAllPlatforms   bool
+	lastPlatformResults  []PlatformVerifyResult
+	registryAuthConfig   *RegistryAuthConfig
+	keylessPolicy        *KeylessPolicy
 }
 
-func (v *ImageSignatureVerifier) Verify() (bool, string, *int64, error) {
+// SignerResults returns the per-signer verification outcomes recorded by the most
+// recent call to Verify() when a MultiSigPolicy is configured. It is empty otherwise.
+func (v *ImageSignatureVerifier) SignerResults() []SignerVerifyResult {
+	return v.lastSignerResults
+}
+
+// PlatformResults returns the per-platform verification outcomes recorded by the most
+// recent call to Verify() when VerifyAllPlatforms is set. It is empty otherwise.
+func (v *ImageSignatureVerifier) PlatformResults() []PlatformVerifyResult {
+	return v.lastPlatformResults
+}
+
+func (v *ImageSignatureVerifier) Verify(ctx context.Context) (bool, string, *int64, error) {
 	imageRef := v.imageRef
 	if imageRef == "" {
 		return false, "", nil, errors.New("no image reference is found")
@@ -89,18 +379,37 @@ func (v *ImageSignatureVerifier) Verify() (bool, string, *int64, error) {
 		pubkeys = []string{""}
 	}
 
+	if (pubkeyPathString == nil || *pubkeyPathString == "") && v.keylessPolicy != nil {
+		return v.verifyKeylessImage(ctx, imageRef)
+	}
+
+	if v.verifyAllPlatforms {
+		return v.verifyAllPlatformImages(ctx, imageRef, pubkeys)
+	}
+
+	if v.multiSigPolicy != nil {
+		return v.verifyWithMultiSigPolicy(ctx, imageRef, pubkeys)
+	}
+
+	keychain, signatureRepository, err := v.registryAuthConfig.keychain(ctx)
+	if err != nil {
+		return false, "", nil, errors.Wrap(err, "failed to resolve registry credentials")
+	}
+
 	verified := false
 	signerName := ""
 	var signedTimestamp *int64
-	var err error
 	if v.onMemoryCacheEnabled {
 		cacheFound := false
 		cacheFoundCount := 0
 		allErrs := []string{}
 		for i := range pubkeys {
+			if ctx.Err() != nil {
+				return false, "", nil, ctx.Err()
+			}
 			pubkey := pubkeys[i]
 			// try getting result from cache
-			cacheFound, verified, signerName, signedTimestamp, err = v.getResultFromCache(imageRef, pubkey)
+			cacheFound, verified, signerName, signedTimestamp, err = v.getResultFromCache(ctx, imageRef, pubkey)
 			// if found and verified true, return it
 			if cacheFound {
 				cacheFoundCount += 1
@@ -120,13 +429,16 @@ func (v *ImageSignatureVerifier) Verify() (bool, string, *int64, error) {
 	log.Debug("image signature cache not found")
 	allErrs := []string{}
 	for i := range pubkeys {
+		if ctx.Err() != nil {
+			return false, "", nil, ctx.Err()
+		}
 		pubkey := pubkeys[i]
 		// do normal image verification
-		verified, signerName, signedTimestamp, err = k8smnfcosign.VerifyImage(imageRef, pubkey)
+		verified, signerName, signedTimestamp, err = k8smnfcosign.VerifyImageWithContext(ctx, imageRef, pubkey, keychain, signatureRepository)
 
 		if v.onMemoryCacheEnabled {
 			// set the result to cache
-			v.setResultToCache(imageRef, pubkey, verified, signerName, signedTimestamp, err)
+			v.setResultToCache(ctx, imageRef, pubkey, verified, signerName, signedTimestamp, err)
 		}
 
 		if verified {
@@ -138,10 +450,10 @@ func (v *ImageSignatureVerifier) Verify() (bool, string, *int64, error) {
 	return false, "", nil, fmt.Errorf("signature verification failed: %s", strings.Join(allErrs, "; "))
 }
 
-func (v *ImageSignatureVerifier) getResultFromCache(imageRef, pubkey string) (bool, bool, string, *int64, error) {
+func (v *ImageSignatureVerifier) getResultFromCache(ctx context.Context, imageRef, pubkey string) (bool, bool, string, *int64, error) {
 	key := fmt.Sprintf("cache/verify-image/%s/%s", imageRef, pubkey)
 	resultNum := 4
-	result, err := k8smnfutil.GetCache(key)
+	result, err := k8smnfutil.GetCacheWithContext(ctx, key)
 	if err != nil {
 		// OnMemoryCache.Get() returns an error only when the key was not found
 		return false, false, "", nil, nil
@@ -167,23 +479,242 @@ func (v *ImageSignatureVerifier) getResultFromCache(imageRef, pubkey string) (bo
 	return true, verified, signerName, signedTimestamp, err
 }
 
-func (v *ImageSignatureVerifier) setResultToCache(imageRef, pubkey string, verified bool, signerName string, signedTimestamp *int64, err error) {
+func (v *ImageSignatureVerifier) setResultToCache(ctx context.Context, imageRef, pubkey string, verified bool, signerName string, signedTimestamp *int64, err error) {
 	key := fmt.Sprintf("cache/verify-image/%s/%s", imageRef, pubkey)
-	setErr := k8smnfutil.SetCache(key, verified, signerName, signedTimestamp, err)
+	setErr := k8smnfutil.SetCacheWithContext(ctx, key, verified, signerName, signedTimestamp, err)
+	if setErr != nil {
+		log.Warn("cache set error: ", setErr.Error())
+	}
+}
+
+// verifyWithMultiSigPolicy discovers every cosign signature layer attached to imageRef,
+// checks each one against every trust anchor in pubkeys, and decides pass/fail by
+// evaluating v.multiSigPolicy against the combined per-layer results. This is what lets a
+// quorum be reached across multiple independent signers on the same image, rather than
+// stopping at the first pubkey/layer pair that verifies.
+func (v *ImageSignatureVerifier) verifyWithMultiSigPolicy(ctx context.Context, imageRef string, pubkeys []string) (bool, string, *int64, error) {
+	keychain, signatureRepository, err := v.registryAuthConfig.keychain(ctx)
+	if err != nil {
+		return false, "", nil, errors.Wrap(err, "failed to resolve registry credentials")
+	}
+	if ctx.Err() != nil {
+		return false, "", nil, ctx.Err()
+	}
+
+	layerResults, err := k8smnfcosign.VerifyImageSignaturesWithContext(ctx, imageRef, pubkeys, keychain, signatureRepository)
+	if err != nil {
+		return false, "", nil, errors.Wrap(err, "failed to enumerate image signature layers")
+	}
+
+	results := make([]SignerVerifyResult, 0, len(layerResults))
+	var latestTimestamp *int64
+	for _, lr := range layerResults {
+		result := SignerVerifyResult{Signer: lr.Signer, Verified: lr.Verified, SignedTimestamp: lr.SignedTimestamp}
+		if lr.Error != nil {
+			result.Error = lr.Error.Error()
+		}
+		results = append(results, result)
+		if lr.Verified {
+			latestTimestamp = lr.SignedTimestamp
+		}
+	}
+	v.lastSignerResults = results
+
+	ok, matchedSigners := v.multiSigPolicy.satisfies(results)
+	if !ok {
+		allErrs := []string{}
+		for _, r := range results {
+			if r.Error != "" {
+				allErrs = append(allErrs, r.Error)
+			}
+		}
+		return false, "", nil, fmt.Errorf("quorum not reached for multi-signature policy: %s", strings.Join(allErrs, "; "))
+	}
+	return true, matchedSigners, latestTimestamp, nil
+}
+
+// verifyAllPlatformImages verifies the index digest itself plus every child manifest
+// referenced by it (filtered through v.platformSelector, if any), returning aggregate
+// pass/fail across all of them. Per-platform outcomes are recorded on
+// v.lastPlatformResults for callers that need the detail.
+func (v *ImageSignatureVerifier) verifyAllPlatformImages(ctx context.Context, imageRef string, pubkeys []string) (bool, string, *int64, error) {
+	indexVerified, indexSigner, indexTimestamp, indexErr := v.verifySingleImageRef(ctx, imageRef, pubkeys)
+
+	keychain, _, err := v.registryAuthConfig.keychain(ctx)
+	if err != nil {
+		return false, "", nil, errors.Wrap(err, "failed to resolve registry credentials")
+	}
+	children, err := k8smnfutil.ListImageIndexChildrenWithContext(ctx, imageRef, keychain)
+	if err != nil {
+		return false, "", nil, errors.Wrap(err, "failed to enumerate manifest-list children")
+	}
+
+	allVerified := indexVerified
+	signers := []string{}
+	if indexVerified && indexSigner != "" {
+		signers = append(signers, indexSigner)
+	}
+	latestTimestamp := indexTimestamp
+	allErrs := []string{}
+	if indexErr != nil {
+		allErrs = append(allErrs, indexErr.Error())
+	}
+
+	results := make([]PlatformVerifyResult, 0, len(children))
+	for _, child := range children {
+		if ctx.Err() != nil {
+			return false, "", nil, ctx.Err()
+		}
+		if !v.platformSelector.matches(child.OS, child.Arch, child.Variant) {
+			continue
+		}
+		childVerified, childSigner, childTimestamp, childErr := v.verifySingleImageRef(ctx, child.Digest, pubkeys)
+		result := PlatformVerifyResult{Digest: child.Digest, OS: child.OS, Arch: child.Arch, Variant: child.Variant, Verified: childVerified, Signer: childSigner, SignedTimestamp: childTimestamp}
+		if childErr != nil {
+			result.Error = childErr.Error()
+			allErrs = append(allErrs, childErr.Error())
+		}
+		results = append(results, result)
+		allVerified = allVerified && childVerified
+		if childVerified {
+			if childSigner != "" {
+				signers = append(signers, childSigner)
+			}
+			latestTimestamp = childTimestamp
+		}
+	}
+	v.lastPlatformResults = results
+
+	if !allVerified {
+		return false, "", nil, fmt.Errorf("signature verification failed for one or more platforms: %s", strings.Join(allErrs, "; "))
+	}
+	return true, strings.Join(signers, ","), latestTimestamp, nil
+}
+
+// verifySingleImageRef tries each configured pubkey against ref in turn, returning the
+// first successful verification, mirroring the fallback behavior of Verify().
+func (v *ImageSignatureVerifier) verifySingleImageRef(ctx context.Context, ref string, pubkeys []string) (bool, string, *int64, error) {
+	keychain, signatureRepository, err := v.registryAuthConfig.keychain(ctx)
+	if err != nil {
+		return false, "", nil, errors.Wrap(err, "failed to resolve registry credentials")
+	}
+
+	allErrs := []string{}
+	for _, pubkey := range pubkeys {
+		if ctx.Err() != nil {
+			return false, "", nil, ctx.Err()
+		}
+		verified, signerName, signedTimestamp, err := k8smnfcosign.VerifyImageWithContext(ctx, ref, pubkey, keychain, signatureRepository)
+		if verified {
+			return true, signerName, signedTimestamp, nil
+		}
+		if err != nil {
+			allErrs = append(allErrs, err.Error())
+		}
+	}
+	return false, "", nil, fmt.Errorf("signature verification failed: %s", strings.Join(allErrs, "; "))
+}
+
+// verifyKeylessImage verifies imageRef using a Fulcio-issued signing certificate and Rekor
+// transparency-log inclusion instead of a static public key, matching the certificate's
+// SAN subject and issuer against v.keylessPolicy. Results are cached by (imageRef, policy
+// hash), since there is no pubkey to key the cache on.
+func (v *ImageSignatureVerifier) verifyKeylessImage(ctx context.Context, imageRef string) (bool, string, *int64, error) {
+	keychain, signatureRepository, err := v.registryAuthConfig.keychain(ctx)
+	if err != nil {
+		return false, "", nil, errors.Wrap(err, "failed to resolve registry credentials")
+	}
+
+	if v.onMemoryCacheEnabled {
+		if cacheFound, verified, signerName, signedTimestamp, cacheErr := v.getKeylessResultFromCache(ctx, imageRef); cacheFound && verified {
+			return verified, signerName, signedTimestamp, cacheErr
+		}
+	}
+
+	verified, subject, issuer, signedTimestamp, err := k8smnfcosign.VerifyImageKeylessWithContext(ctx, imageRef, keychain, signatureRepository, v.keylessPolicy.RekorPublicKeys, v.keylessPolicy.CTLogPublicKeys, v.keylessPolicy.FulcioRootCertificates)
+	signerName := subject
+	if verified && !v.keylessPolicy.allowed(subject, issuer) {
+		verified = false
+		signerName = ""
+		err = fmt.Errorf("signing identity %q (issuer %q) does not satisfy the configured keyless policy", subject, issuer)
+	}
+
+	if v.onMemoryCacheEnabled {
+		v.setKeylessResultToCache(ctx, imageRef, verified, signerName, signedTimestamp, err)
+	}
+
+	if !verified {
+		return false, "", nil, err
+	}
+	return true, signerName, signedTimestamp, nil
+}
+
+func (v *ImageSignatureVerifier) getKeylessResultFromCache(ctx context.Context, imageRef string) (bool, bool, string, *int64, error) {
+	key := fmt.Sprintf("cache/verify-image-keyless/%s/%s", imageRef, v.keylessPolicy.hash())
+	resultNum := 4
+	result, err := k8smnfutil.GetCacheWithContext(ctx, key)
+	if err != nil {
+		// GetCacheWithContext() returns an error when the key is missing or expired or ctx is
+		// done; each is treated as a cache miss
+		return false, false, "", nil, nil
+	}
+	if len(result) != resultNum {
+		return false, false, "", nil, fmt.Errorf("cache returns inconsistent data: a length of verify image result must be %v, but got %v", resultNum, len(result))
+	}
+	verified := false
+	signerName := ""
+	var signedTimestamp *int64
+	if result[0] != nil {
+		verified = result[0].(bool)
+	}
+	if result[1] != nil {
+		signerName = result[1].(string)
+	}
+	if result[2] != nil {
+		signedTimestamp = result[2].(*int64)
+	}
+	if result[3] != nil {
+		err = result[3].(error)
+	}
+	return true, verified, signerName, signedTimestamp, err
+}
+
+func (v *ImageSignatureVerifier) setKeylessResultToCache(ctx context.Context, imageRef string, verified bool, signerName string, signedTimestamp *int64, err error) {
+	key := fmt.Sprintf("cache/verify-image-keyless/%s/%s", imageRef, v.keylessPolicy.hash())
+	setErr := k8smnfutil.SetCacheWithContext(ctx, key, verified, signerName, signedTimestamp, err)
 	if setErr != nil {
 		log.Warn("cache set error: ", setErr.Error())
 	}
 }
 
 type BlobSignatureVerifier struct {
-	annotations      map[string]string
-	resourceRef      string
-	pubkeyPathString *string
-	annotationConfig AnnotationConfig
+	annotations       map[string]string
+	resourceRef       string
+	pubkeyPathString  *string
+	annotationConfig  AnnotationConfig
+	multiSigPolicy    *MultiSigPolicy
+	lastSignerResults []SignerVerifyResult
+	keylessPolicy     *KeylessPolicy
+}
+
+// SignerResults returns the per-signer verification outcomes recorded by the most
+// recent call to Verify() when a MultiSigPolicy is configured. It is empty otherwise.
+func (v *BlobSignatureVerifier) SignerResults() []SignerVerifyResult {
+	return v.lastSignerResults
 }
 
-func (v *BlobSignatureVerifier) Verify() (bool, string, *int64, error) {
-	sigMap, err := v.getSignatures()
+// multiSigTuple is the shape of one entry when the message annotation holds a JSON
+// array of signatures instead of a single detached signature, mirroring how several
+// libtrust JWS signatures can be attached to one payload.
+type multiSigTuple struct {
+	Signer string `json:"signer"`
+	Sig    string `json:"sig"`
+	Cert   string `json:"cert"`
+	Bundle string `json:"bundle"`
+}
+
+func (v *BlobSignatureVerifier) Verify(ctx context.Context) (bool, string, *int64, error) {
+	sigMap, err := v.getSignatures(ctx)
 	if err != nil {
 		return false, "", nil, errors.Wrap(err, "failed to get signature")
 	}
@@ -193,6 +724,17 @@ func (v *BlobSignatureVerifier) Verify() (bool, string, *int64, error) {
 	certBytes := sigMap[CertificateAnnotationBaseName]
 	bundleBytes := sigMap[BundleAnnotationBaseName]
 
+	if v.multiSigPolicy != nil {
+		var tuples []multiSigTuple
+		if err := json.Unmarshal(sigBytes, &tuples); err == nil {
+			return v.verifyWithMultiSigPolicy(ctx, msgBytes, tuples)
+		}
+	}
+
+	if (v.pubkeyPathString == nil || *v.pubkeyPathString == "") && v.keylessPolicy != nil && len(certBytes) > 0 {
+		return v.verifyKeylessBlob(ctx, msgBytes, sigBytes, certBytes, bundleBytes)
+	}
+
 	sigType := sigtypes.GetSignatureTypeFromPublicKey(v.pubkeyPathString)
 	if sigType == sigtypes.SigTypeUnknown {
 		return false, "", nil, errors.New("failed to judge signature type from public key configuration")
@@ -207,13 +749,84 @@ func (v *BlobSignatureVerifier) Verify() (bool, string, *int64, error) {
 	return false, "", nil, errors.New("unknown error")
 }
 
-func (v *BlobSignatureVerifier) getSignatures() (map[string][]byte, error) {
+// verifyWithMultiSigPolicy verifies each {sig, cert, bundle, signer} tuple independently
+// against the same msgBytes payload — trying every configured trust-anchor pubkey for each
+// tuple, since VerifyBlob itself only checks one — and decides pass/fail by evaluating
+// v.multiSigPolicy against the combined results, mirroring how ImageSignatureVerifier
+// handles multiple trust anchors.
+func (v *BlobSignatureVerifier) verifyWithMultiSigPolicy(ctx context.Context, msgBytes []byte, tuples []multiSigTuple) (bool, string, *int64, error) {
+	var pubkeys []string
+	if v.pubkeyPathString != nil && *v.pubkeyPathString != "" {
+		pubkeys = k8smnfutil.SplitCommaSeparatedString(*v.pubkeyPathString)
+	} else {
+		pubkeys = []string{""}
+	}
+
+	results := make([]SignerVerifyResult, 0, len(tuples))
+	var latestTimestamp *int64
+	for _, t := range tuples {
+		if ctx.Err() != nil {
+			return false, "", nil, ctx.Err()
+		}
+		var verified bool
+		var signedTimestamp *int64
+		var verifyErr error
+		for _, pubkey := range pubkeys {
+			pubkey := pubkey
+			verified, _, signedTimestamp, verifyErr = k8smnfcosign.VerifyBlob(msgBytes, []byte(t.Sig), []byte(t.Cert), []byte(t.Bundle), &pubkey)
+			if verified {
+				break
+			}
+		}
+		result := SignerVerifyResult{Signer: t.Signer, Verified: verified, SignedTimestamp: signedTimestamp}
+		if !verified && verifyErr != nil {
+			result.Error = verifyErr.Error()
+		}
+		results = append(results, result)
+		if verified {
+			latestTimestamp = signedTimestamp
+		}
+	}
+	v.lastSignerResults = results
+
+	ok, matchedSigners := v.multiSigPolicy.satisfies(results)
+	if !ok {
+		allErrs := []string{}
+		for _, r := range results {
+			if r.Error != "" {
+				allErrs = append(allErrs, r.Error)
+			}
+		}
+		return false, "", nil, fmt.Errorf("quorum not reached for multi-signature policy: %s", strings.Join(allErrs, "; "))
+	}
+	return true, matchedSigners, latestTimestamp, nil
+}
+
+// verifyKeylessBlob verifies msgBytes/sigBytes against the leaf certificate in certBytes,
+// checking its chain against the Fulcio roots and Rekor transparency-log inclusion, and
+// matching its SAN subject and issuer against v.keylessPolicy, instead of relying on a
+// configured static public key.
+func (v *BlobSignatureVerifier) verifyKeylessBlob(ctx context.Context, msgBytes, sigBytes, certBytes, bundleBytes []byte) (bool, string, *int64, error) {
+	if ctx.Err() != nil {
+		return false, "", nil, ctx.Err()
+	}
+	verified, subject, issuer, signedTimestamp, err := k8smnfcosign.VerifyBlobKeyless(msgBytes, sigBytes, certBytes, bundleBytes, v.keylessPolicy.RekorPublicKeys, v.keylessPolicy.FulcioRootCertificates)
+	if err != nil {
+		return false, "", nil, err
+	}
+	if verified && !v.keylessPolicy.allowed(subject, issuer) {
+		return false, "", nil, fmt.Errorf("signing identity %q (issuer %q) does not satisfy the configured keyless policy", subject, issuer)
+	}
+	return verified, subject, signedTimestamp, nil
+}
+
+func (v *BlobSignatureVerifier) getSignatures(ctx context.Context) (map[string][]byte, error) {
 	sigMap := map[string][]byte{}
 	var msg, sig, cert, bundle string
 	var ok bool
 	if v.resourceRef != "" {
 		cmRef := v.resourceRef
-		cm, err := GetConfigMapFromK8sObjectRef(cmRef)
+		cm, err := GetConfigMapFromK8sObjectRefWithContext(ctx, cmRef)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to get a configmap")
 		}
@@ -259,10 +872,201 @@ func (v *BlobSignatureVerifier) getSignatures() (map[string][]byte, error) {
 	return sigMap, nil
 }
 
+// Supported in-toto attestation predicate types.
+const (
+	PredicateTypeSLSAProvenanceV02 = "https://slsa.dev/provenance/v0.2"
+	PredicateTypeSPDXDocument      = "https://spdx.dev/Document"
+	PredicateTypeVulnV1            = "cosign.sigstore.dev/attestation/vuln/v1"
+)
+
+// AttestationResult carries one cosign attestation's predicate type, issuer and raw
+// in-toto statement payload, regardless of whether it matched the configured policy.
+type AttestationResult struct {
+	PredicateType string          `json:"predicateType"`
+	Issuer        string          `json:"issuer,omitempty"`
+	Statement     json.RawMessage `json:"statement"`
+}
+
+// AttestationPolicy decides which attestations are acceptable. PredicateTypes restricts
+// matching to the listed predicate types (empty accepts any predicate type); Matchers is a
+// simple JSON matcher: each dotted path from the root of the in-toto statement (e.g.
+// "predicate.builder.id") must equal the given string for the attestation to satisfy the policy.
+// All entries in Matchers must match (AND semantics).
+type AttestationPolicy struct {
+	PredicateTypes []string
+	Matchers       map[string]string
+}
+
+func (p *AttestationPolicy) matches(predicateType string, statement map[string]interface{}) bool {
+	if p == nil {
+		return true
+	}
+	if len(p.PredicateTypes) > 0 {
+		found := false
+		for _, t := range p.PredicateTypes {
+			if t == predicateType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for path, want := range p.Matchers {
+		got, found := lookupJSONPath(statement, path)
+		if !found || fmt.Sprintf("%v", got) != want {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupJSONPath walks a dot-separated path (e.g. "predicate.builder.id") through nested
+// map[string]interface{} values as produced by json.Unmarshal into an interface{}.
+func lookupJSONPath(m map[string]interface{}, path string) (interface{}, bool) {
+	var cur interface{} = m
+	for _, part := range strings.Split(path, ".") {
+		cm, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		cur, ok = cm[part]
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// dsseEnvelope is the DSSE (Dead Simple Signing Envelope) wrapper that cosign attaches
+// in-toto attestations inside.
+type dsseEnvelope struct {
+	Payload     string          `json:"payload"`
+	PayloadType string          `json:"payloadType"`
+	Signatures  []dsseSignature `json:"signatures"`
+}
+
+type dsseSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// ImageAttestationVerifier implements SignatureVerifier by fetching cosign attestations
+// (rather than a plain signature) over imageRef, decoding each DSSE envelope's enclosed
+// in-toto Statement, and validating its predicate against policy. This is how operators
+// enforce "manifest was produced by a trusted builder" rather than merely "manifest was
+// signed".
+type ImageAttestationVerifier struct {
+	imageRef           string
+	pubkeyPathString   *string
+	annotationConfig   AnnotationConfig
+	policy             *AttestationPolicy
+	registryAuthConfig *RegistryAuthConfig
+	lastAttestations   []AttestationResult
+}
+
+// NewImageAttestationVerifier builds an ImageAttestationVerifier for imageRef. A nil
+// policy accepts any attestation, whatever its predicate type.
+func NewImageAttestationVerifier(imageRef string, pubkeyPath *string, annotationConfig AnnotationConfig, policy *AttestationPolicy, registryAuthConfig *RegistryAuthConfig) *ImageAttestationVerifier {
+	var pubkeyPathString *string
+	if pubkeyPath != nil && *pubkeyPath != "" {
+		pubkeyPathString = pubkeyPath
+	}
+	return &ImageAttestationVerifier{
+		imageRef:           imageRef,
+		pubkeyPathString:   pubkeyPathString,
+		annotationConfig:   annotationConfig,
+		policy:             policy,
+		registryAuthConfig: registryAuthConfig,
+	}
+}
+
+// Attestations returns the attestations recorded by the most recent call to Verify(),
+// regardless of whether they individually matched the configured policy.
+func (v *ImageAttestationVerifier) Attestations() []AttestationResult {
+	return v.lastAttestations
+}
+
+func (v *ImageAttestationVerifier) Verify(ctx context.Context) (bool, string, *int64, error) {
+	if v.imageRef == "" {
+		return false, "", nil, errors.New("no image reference is found")
+	}
+
+	keychain, signatureRepository, err := v.registryAuthConfig.keychain(ctx)
+	if err != nil {
+		return false, "", nil, errors.Wrap(err, "failed to resolve registry credentials")
+	}
+
+	var pubkeys []string
+	if v.pubkeyPathString != nil && *v.pubkeyPathString != "" {
+		pubkeys = k8smnfutil.SplitCommaSeparatedString(*v.pubkeyPathString)
+	} else {
+		pubkeys = []string{""}
+	}
+
+	allErrs := []string{}
+	for _, pubkey := range pubkeys {
+		if ctx.Err() != nil {
+			return false, "", nil, ctx.Err()
+		}
+		envelopes, issuers, signerName, signedTimestamp, err := k8smnfcosign.VerifyImageAttestationsWithContext(ctx, v.imageRef, pubkey, keychain, signatureRepository)
+		if err != nil {
+			allErrs = append(allErrs, err.Error())
+			continue
+		}
+		results, matched, err := v.evaluateAttestations(envelopes, issuers)
+		v.lastAttestations = results
+		if err != nil {
+			allErrs = append(allErrs, err.Error())
+			continue
+		}
+		if matched {
+			return true, signerName, signedTimestamp, nil
+		}
+		allErrs = append(allErrs, "no attestation satisfied the configured policy")
+	}
+	return false, "", nil, fmt.Errorf("attestation verification failed: %s", strings.Join(allErrs, "; "))
+}
+
+// evaluateAttestations decodes each DSSE envelope into its in-toto Statement and checks
+// it against v.policy, returning the full per-attestation detail plus whether any one of
+// them satisfied the policy. issuers must be parallel to envelopes, giving the Fulcio/OIDC
+// issuer identity that signed each one; the DSSE envelope's own Signatures[].KeyID is the
+// cosign signing key's ID, not an issuer, and must not be used for AttestationResult.Issuer.
+func (v *ImageAttestationVerifier) evaluateAttestations(envelopes [][]byte, issuers []string) ([]AttestationResult, bool, error) {
+	results := make([]AttestationResult, 0, len(envelopes))
+	matched := false
+	for i, envelopeBytes := range envelopes {
+		var envelope dsseEnvelope
+		if err := json.Unmarshal(envelopeBytes, &envelope); err != nil {
+			return results, false, errors.Wrap(err, "failed to parse DSSE envelope")
+		}
+		payloadBytes, err := base64.StdEncoding.DecodeString(envelope.Payload)
+		if err != nil {
+			return results, false, errors.Wrap(err, "failed to decode DSSE payload")
+		}
+		var statement map[string]interface{}
+		if err := json.Unmarshal(payloadBytes, &statement); err != nil {
+			return results, false, errors.Wrap(err, "failed to parse in-toto statement")
+		}
+		predicateType, _ := statement["predicateType"].(string)
+		var issuer string
+		if i < len(issuers) {
+			issuer = issuers[i]
+		}
+		results = append(results, AttestationResult{PredicateType: predicateType, Issuer: issuer, Statement: json.RawMessage(payloadBytes)})
+		if v.policy.matches(predicateType, statement) {
+			matched = true
+		}
+	}
+	return results, matched, nil
+}
+
 // This is an interface for fetching YAML manifest
 // a function Fetch() fetches a YAML manifest which matches the input object's kind, name and so on
 type ManifestFetcher interface {
-	Fetch(objYAMLBytes []byte) ([][]byte, string, error)
+	Fetch(ctx context.Context, objYAMLBytes []byte) ([][]byte, string, error)
 }
 
 // return a manifest fetcher.
@@ -270,8 +1074,26 @@ type ManifestFetcher interface {
 // `annotationConfig` is used for annotation domain config like "cosign.sigstore.dev".
 // `ignoreFields` and `maxResourceManifestNum` are used inside manifest detection logic.
 func NewManifestFetcher(imageRef, resourceRef string, annotationConfig AnnotationConfig, ignoreFields []string, maxResourceManifestNum int) ManifestFetcher {
+	return newManifestFetcher(imageRef, resourceRef, annotationConfig, ignoreFields, maxResourceManifestNum, nil, nil)
+}
+
+// NewManifestFetcherWithPlatformSelector is like NewManifestFetcher but additionally accepts
+// a PlatformSelector used to pick a single child manifest when imageRef resolves to an OCI
+// index / Docker manifest list. A nil selector merges the YAMLs of every child instead.
+func NewManifestFetcherWithPlatformSelector(imageRef, resourceRef string, annotationConfig AnnotationConfig, ignoreFields []string, maxResourceManifestNum int, platformSelector *PlatformSelector) ManifestFetcher {
+	return newManifestFetcher(imageRef, resourceRef, annotationConfig, ignoreFields, maxResourceManifestNum, platformSelector, nil)
+}
+
+// NewManifestFetcherWithRegistryAuth is like NewManifestFetcherWithPlatformSelector but
+// additionally accepts a RegistryAuthConfig for pulling from private or authenticated
+// registries. A nil config falls back to authn.DefaultKeychain.
+func NewManifestFetcherWithRegistryAuth(imageRef, resourceRef string, annotationConfig AnnotationConfig, ignoreFields []string, maxResourceManifestNum int, platformSelector *PlatformSelector, registryAuthConfig *RegistryAuthConfig) ManifestFetcher {
+	return newManifestFetcher(imageRef, resourceRef, annotationConfig, ignoreFields, maxResourceManifestNum, platformSelector, registryAuthConfig)
+}
+
+func newManifestFetcher(imageRef, resourceRef string, annotationConfig AnnotationConfig, ignoreFields []string, maxResourceManifestNum int, platformSelector *PlatformSelector, registryAuthConfig *RegistryAuthConfig) ManifestFetcher {
 	if imageRef != "" {
-		return &ImageManifestFetcher{imageRefString: imageRef, AnnotationConfig: annotationConfig, ignoreFields: ignoreFields, maxResourceManifestNum: maxResourceManifestNum, cacheEnabled: true}
+		return &ImageManifestFetcher{imageRefString: imageRef, AnnotationConfig: annotationConfig, ignoreFields: ignoreFields, maxResourceManifestNum: maxResourceManifestNum, cacheEnabled: true, platformSelector: platformSelector, registryAuthConfig: registryAuthConfig}
 	} else {
 		return &BlobManifestFetcher{AnnotationConfig: annotationConfig, resourceRefString: resourceRef, ignoreFields: ignoreFields, maxResourceManifestNum: maxResourceManifestNum}
 	}
@@ -284,9 +1106,11 @@ type ImageManifestFetcher struct {
 	ignoreFields           []string // used by ManifestSearchByValue()
 	maxResourceManifestNum int      // used by ManifestSearchByValue()
 	cacheEnabled           bool
+	platformSelector       *PlatformSelector
+	registryAuthConfig     *RegistryAuthConfig
 }
 
-func (f *ImageManifestFetcher) Fetch(objYAMLBytes []byte) ([][]byte, string, error) {
+func (f *ImageManifestFetcher) Fetch(ctx context.Context, objYAMLBytes []byte) ([][]byte, string, error) {
 	imageRefString := f.imageRefString
 	imageRefAnnotationKey := f.AnnotationConfig.ImageRefAnnotationKey()
 	if imageRefString == "" {
@@ -306,7 +1130,10 @@ func (f *ImageManifestFetcher) Fetch(objYAMLBytes []byte) ([][]byte, string, err
 
 	imageRefList := k8smnfutil.SplitCommaSeparatedString(imageRefString)
 	for _, imageRef := range imageRefList {
-		concatYAMLbytes, err := f.fetchManifestInSingleImage(imageRef)
+		if ctx.Err() != nil {
+			return nil, "", ctx.Err()
+		}
+		concatYAMLbytes, err := f.fetchManifestInSingleImage(ctx, imageRef)
 		if err != nil {
 			return nil, "", err
 		}
@@ -318,26 +1145,26 @@ func (f *ImageManifestFetcher) Fetch(objYAMLBytes []byte) ([][]byte, string, err
 	return nil, "", errors.New("failed to find a YAML manifest in the image")
 }
 
-func (f *ImageManifestFetcher) fetchManifestInSingleImage(singleImageRef string) ([]byte, error) {
+func (f *ImageManifestFetcher) fetchManifestInSingleImage(ctx context.Context, singleImageRef string) ([]byte, error) {
 	var concatYAMLbytes []byte
 	var err error
 	if f.cacheEnabled {
 		cacheFound := false
 		// try getting YAML manifests from cache
-		cacheFound, concatYAMLbytes, err = f.getManifestFromCache(singleImageRef)
+		cacheFound, concatYAMLbytes, err = f.getManifestFromCache(ctx, singleImageRef)
 		// if cache not found, do fetch and set the result to cache
 		if !cacheFound {
 			log.Debug("image manifest cache not found")
 			// fetch YAML manifests from actual image
-			concatYAMLbytes, err = f.getConcatYAMLFromImageRef(singleImageRef)
+			concatYAMLbytes, err = f.getConcatYAMLFromImageRef(ctx, singleImageRef)
 			if err == nil {
 				// set the result to cache
-				f.setManifestToCache(singleImageRef, concatYAMLbytes, err)
+				f.setManifestToCache(ctx, singleImageRef, concatYAMLbytes, err)
 			}
 		}
 	} else {
 		// fetch YAML manifests from actual image
-		concatYAMLbytes, err = f.getConcatYAMLFromImageRef(singleImageRef)
+		concatYAMLbytes, err = f.getConcatYAMLFromImageRef(ctx, singleImageRef)
 	}
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get YAMLs in the image")
@@ -345,13 +1172,13 @@ func (f *ImageManifestFetcher) fetchManifestInSingleImage(singleImageRef string)
 	return concatYAMLbytes, nil
 }
 
-func (f *ImageManifestFetcher) FetchAll() ([][]byte, error) {
+func (f *ImageManifestFetcher) FetchAll(ctx context.Context) ([][]byte, error) {
 	imageRefString := f.imageRefString
 	imageRefList := k8smnfutil.SplitCommaSeparatedString(imageRefString)
 
 	yamls := [][]byte{}
 	for _, imageRef := range imageRefList {
-		concatYAMLbytes, err := f.fetchManifestInSingleImage(imageRef)
+		concatYAMLbytes, err := f.fetchManifestInSingleImage(ctx, imageRef)
 		if err != nil {
 			return nil, err
 		}
@@ -361,11 +1188,18 @@ func (f *ImageManifestFetcher) FetchAll() ([][]byte, error) {
 	return yamls, nil
 }
 
-func (f *ImageManifestFetcher) getConcatYAMLFromImageRef(imageRef string) ([]byte, error) {
-	image, err := k8smnfutil.PullImage(imageRef)
+func (f *ImageManifestFetcher) getConcatYAMLFromImageRef(ctx context.Context, imageRef string) ([]byte, error) {
+	keychain, _, err := f.registryAuthConfig.keychain(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve registry credentials")
+	}
+	image, err := k8smnfutil.PullImageWithContext(ctx, imageRef, keychain)
 	if err != nil {
 		return nil, err
 	}
+	if k8smnfutil.IsImageIndex(image) {
+		return f.getConcatYAMLFromImageIndex(ctx, imageRef)
+	}
 	concatYAMLbytes, err := k8smnfutil.GenerateConcatYAMLsFromImage(image)
 	if err != nil {
 		return nil, err
@@ -373,10 +1207,53 @@ func (f *ImageManifestFetcher) getConcatYAMLFromImageRef(imageRef string) ([]byt
 	return concatYAMLbytes, nil
 }
 
-func (f *ImageManifestFetcher) getManifestFromCache(imageRef string) (bool, []byte, error) {
+// getConcatYAMLFromImageIndex handles imageRef pointing at an OCI index / Docker manifest
+// list: if f.platformSelector is set, it fetches YAMLs from the single matching child;
+// otherwise it fetches from every child and concatenates the results.
+func (f *ImageManifestFetcher) getConcatYAMLFromImageIndex(ctx context.Context, imageRef string) ([]byte, error) {
+	keychain, _, err := f.registryAuthConfig.keychain(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve registry credentials")
+	}
+	children, err := k8smnfutil.ListImageIndexChildrenWithContext(ctx, imageRef, keychain)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to enumerate manifest-list children")
+	}
+
+	childYAMLs := [][]byte{}
+	for _, child := range children {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if !f.platformSelector.matches(child.OS, child.Arch, child.Variant) {
+			continue
+		}
+		childImage, err := k8smnfutil.PullImageWithContext(ctx, child.Digest, keychain)
+		if err != nil {
+			return nil, err
+		}
+		childYAMLbytes, err := k8smnfutil.GenerateConcatYAMLsFromImage(childImage)
+		if err != nil {
+			return nil, err
+		}
+		childYAMLs = append(childYAMLs, childYAMLbytes)
+		if f.platformSelector != nil {
+			break
+		}
+	}
+	if len(childYAMLs) == 0 {
+		return nil, errors.New("no manifest-list child matched the platform selector")
+	}
+	// Join with the same "---" document separator k8smnfutil.ConcatenateYAMLs uses for the
+	// blob/tarball case, so a child YAML missing a trailing newline can't run into the next
+	// child's first document when FindManifestYAML/SplitConcatYAMLs later parse this blob.
+	return k8smnfutil.ConcatenateYAMLs(childYAMLs), nil
+}
+
+func (f *ImageManifestFetcher) getManifestFromCache(ctx context.Context, imageRef string) (bool, []byte, error) {
 	key := fmt.Sprintf("cache/fetch-manifest/%s", imageRef)
 	resultNum := 2
-	result, err := k8smnfutil.GetCache(key)
+	result, err := k8smnfutil.GetCacheWithContext(ctx, key)
 	if err != nil {
 		// OnMemoryCache.Get() returns an error only when the key was not found
 		return false, nil, nil
@@ -400,9 +1277,9 @@ func (f *ImageManifestFetcher) getManifestFromCache(imageRef string) (bool, []by
 	return true, concatYAMLbytes, err
 }
 
-func (f *ImageManifestFetcher) setManifestToCache(imageRef string, concatYAMLbytes []byte, err error) {
+func (f *ImageManifestFetcher) setManifestToCache(ctx context.Context, imageRef string, concatYAMLbytes []byte, err error) {
 	key := fmt.Sprintf("cache/fetch-manifest/%s", imageRef)
-	setErr := k8smnfutil.SetCache(key, concatYAMLbytes, err)
+	setErr := k8smnfutil.SetCacheWithContext(ctx, key, concatYAMLbytes, err)
 	if setErr != nil {
 		log.Warn("cache set error: ", setErr.Error())
 	}
@@ -415,9 +1292,9 @@ type BlobManifestFetcher struct {
 	maxResourceManifestNum int      // used by ManifestSearchByValue()
 }
 
-func (f *BlobManifestFetcher) Fetch(objYAMLBytes []byte) ([][]byte, string, error) {
+func (f *BlobManifestFetcher) Fetch(ctx context.Context, objYAMLBytes []byte) ([][]byte, string, error) {
 	if f.resourceRefString != "" {
-		return f.fetchManifestFromResource(objYAMLBytes)
+		return f.fetchManifestFromResource(ctx, objYAMLBytes)
 	}
 
 	annotations := k8smnfutil.GetAnnotationsInYAML(objYAMLBytes)
@@ -453,7 +1330,7 @@ func (f *BlobManifestFetcher) Fetch(objYAMLBytes []byte) ([][]byte, string, erro
 	return resourceManifests, SigRefEmbeddedInAnnotation, nil
 }
 
-func (f *BlobManifestFetcher) fetchManifestFromResource(objYAMLBytes []byte) ([][]byte, string, error) {
+func (f *BlobManifestFetcher) fetchManifestFromResource(ctx context.Context, objYAMLBytes []byte) ([][]byte, string, error) {
 	resourceRefString := f.resourceRefString
 	if resourceRefString == "" {
 		return nil, "", errors.New("no signature resource reference is specified")
@@ -466,7 +1343,10 @@ func (f *BlobManifestFetcher) fetchManifestFromResource(objYAMLBytes []byte) ([]
 
 	resourceRefList := k8smnfutil.SplitCommaSeparatedString(resourceRefString)
 	for _, resourceRef := range resourceRefList {
-		concatYAMLbytes, err := f.fetchManifestInSingleConfigMap(resourceRef)
+		if ctx.Err() != nil {
+			return nil, "", ctx.Err()
+		}
+		concatYAMLbytes, err := f.fetchManifestInSingleConfigMap(ctx, resourceRef)
 		if err != nil {
 			return nil, "", err
 		}
@@ -478,8 +1358,8 @@ func (f *BlobManifestFetcher) fetchManifestFromResource(objYAMLBytes []byte) ([]
 	return nil, "", errors.New("failed to find a YAML manifest in the specified signature configmaps")
 }
 
-func (f *BlobManifestFetcher) fetchManifestInSingleConfigMap(singleCMRef string) ([]byte, error) {
-	cm, err := GetConfigMapFromK8sObjectRef(singleCMRef)
+func (f *BlobManifestFetcher) fetchManifestInSingleConfigMap(ctx context.Context, singleCMRef string) ([]byte, error) {
+	cm, err := GetConfigMapFromK8sObjectRefWithContext(ctx, singleCMRef)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get a configmap")
 	}
@@ -503,9 +1383,11 @@ func (f *BlobManifestFetcher) fetchManifestInSingleConfigMap(singleCMRef string)
 }
 
 type VerifyResult struct {
-	Verified bool                `json:"verified"`
-	Signer   string              `json:"signer"`
-	Diff     *mapnode.DiffResult `json:"diff"`
+	Verified     bool                 `json:"verified"`
+	Signer       string               `json:"signer"`
+	Diff         *mapnode.DiffResult  `json:"diff"`
+	Signers      []SignerVerifyResult `json:"signers,omitempty"`
+	Attestations []AttestationResult  `json:"attestations,omitempty"`
 }
 
 func (r *VerifyResult) String() string {
@@ -513,7 +1395,13 @@ func (r *VerifyResult) String() string {
 	return string(rB)
 }
 
+// GetConfigMapFromK8sObjectRef is kept for callers that do not need cancellation; it
+// delegates to GetConfigMapFromK8sObjectRefWithContext using context.Background().
 func GetConfigMapFromK8sObjectRef(objRef string) (*corev1.ConfigMap, error) {
+	return GetConfigMapFromK8sObjectRefWithContext(context.Background(), objRef)
+}
+
+func GetConfigMapFromK8sObjectRefWithContext(ctx context.Context, objRef string) (*corev1.ConfigMap, error) {
 	kind, ns, name, err := parseObjectInCluster(objRef)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to parse a configmap reference")
@@ -521,7 +1409,7 @@ func GetConfigMapFromK8sObjectRef(objRef string) (*corev1.ConfigMap, error) {
 	if kind != "ConfigMap" && kind != "configmaps" && kind != "cm" {
 		return nil, fmt.Errorf("configmap reference must be \"k8s://ConfigMap/[NAMESPACE]/[NAME]\", but got %s", objRef)
 	}
-	cmObj, err := kubeutil.GetResource("", kind, ns, name)
+	cmObj, err := kubeutil.GetResourceWithContext(ctx, "", kind, ns, name)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get a configmap")
 	}
@@ -0,0 +1,86 @@
+//
+// Copyright 2020 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package util
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pkg/errors"
+)
+
+// dockerConfigFile mirrors the subset of a docker config.json this package needs: a
+// per-registry map of base64("username:password") auth strings.
+type dockerConfigFile struct {
+	Auths map[string]struct {
+		Auth string `json:"auth"`
+	} `json:"auths"`
+}
+
+// KeychainFromDockerConfig builds an authn.Keychain from the docker config.json at
+// configPath (as written by `docker login`), for pulling images and signatures from a
+// registry whose credentials are not available through the ambient environment or a
+// mounted imagePullSecret.
+func KeychainFromDockerConfig(configPath string) (authn.Keychain, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read docker config file")
+	}
+	return KeychainFromDockerConfigJSON(data)
+}
+
+// KeychainFromDockerConfigJSON parses a raw docker config.json / .dockerconfigjson document
+// (as written by `docker login`, or mounted from a kubernetes.io/dockerconfigjson
+// imagePullSecret) into an authn.Keychain that resolves credentials by exact registry
+// hostname match. Shared by KeychainFromDockerConfig and kubeutil.GetKeychainFromImagePullSecretWithContext
+// so the two credential sources parse the same document the same way.
+func KeychainFromDockerConfigJSON(configJSON []byte) (authn.Keychain, error) {
+	var cfg dockerConfigFile
+	if err := json.Unmarshal(configJSON, &cfg); err != nil {
+		return nil, errors.Wrap(err, "failed to parse docker config JSON")
+	}
+
+	creds := map[string]authn.AuthConfig{}
+	for registry, entry := range cfg.Auths {
+		decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+		if err != nil {
+			continue
+		}
+		userPass := strings.SplitN(string(decoded), ":", 2)
+		if len(userPass) != 2 {
+			continue
+		}
+		creds[registry] = authn.AuthConfig{Username: userPass[0], Password: userPass[1]}
+	}
+	return &dockerConfigKeychain{creds: creds}, nil
+}
+
+// dockerConfigKeychain resolves an authn.Authenticator by exact registry hostname match
+// against a pre-parsed docker config.json.
+type dockerConfigKeychain struct {
+	creds map[string]authn.AuthConfig
+}
+
+func (k *dockerConfigKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	if cfg, ok := k.creds[target.RegistryStr()]; ok {
+		return authn.FromConfig(cfg), nil
+	}
+	return authn.Anonymous, nil
+}
@@ -0,0 +1,90 @@
+//
+// Copyright 2020 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// cacheTTL bounds how long a fetched manifest or verification result is trusted before a
+// fresh lookup is forced, so a compromised registry cannot poison the cache indefinitely.
+const cacheTTL = 5 * time.Minute
+
+type cacheEntry struct {
+	values    []interface{}
+	expiresAt time.Time
+}
+
+// OnMemoryCache is a process-local, TTL-based cache keyed by string, used to avoid
+// re-verifying the same image/pubkey pair or re-fetching the same image's manifest on
+// every call.
+type OnMemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+var defaultCache = &OnMemoryCache{entries: map[string]cacheEntry{}}
+
+// Get returns the cached values for key, or an error if key is not present or has expired.
+func (c *OnMemoryCache) Get(key string) ([]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, fmt.Errorf("cache key not found: %s", key)
+	}
+	return entry.values, nil
+}
+
+// Set stores values under key, overwriting any existing entry and its TTL.
+func (c *OnMemoryCache) Set(key string, values ...interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cacheEntry{values: values, expiresAt: time.Now().Add(cacheTTL)}
+	return nil
+}
+
+// GetCache is kept for callers that do not need cancellation; it delegates to
+// GetCacheWithContext using context.Background().
+func GetCache(key string) ([]interface{}, error) {
+	return GetCacheWithContext(context.Background(), key)
+}
+
+// GetCacheWithContext returns the cached values for key from the default on-memory cache.
+func GetCacheWithContext(ctx context.Context, key string) ([]interface{}, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return defaultCache.Get(key)
+}
+
+// SetCache is kept for callers that do not need cancellation; it delegates to
+// SetCacheWithContext using context.Background().
+func SetCache(key string, values ...interface{}) error {
+	return SetCacheWithContext(context.Background(), key, values...)
+}
+
+// SetCacheWithContext stores values under key in the default on-memory cache.
+func SetCacheWithContext(ctx context.Context, key string, values ...interface{}) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return defaultCache.Set(key, values...)
+}
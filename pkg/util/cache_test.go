@@ -0,0 +1,54 @@
+//
+// Copyright 2020 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package util
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCacheRoundTrip(t *testing.T) {
+	key := "cache/verify-image/test-round-trip"
+	if err := SetCacheWithContext(context.Background(), key, true, "alice", (*int64)(nil)); err != nil {
+		t.Fatalf("SetCacheWithContext() error = %v", err)
+	}
+	values, err := GetCacheWithContext(context.Background(), key)
+	if err != nil {
+		t.Fatalf("GetCacheWithContext() error = %v", err)
+	}
+	if len(values) != 3 || values[1] != "alice" {
+		t.Errorf("GetCacheWithContext() = %v, want [true alice <nil>]", values)
+	}
+}
+
+func TestCacheRespectsCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := SetCacheWithContext(ctx, "cache/verify-image/cancelled", true); err == nil {
+		t.Error("SetCacheWithContext() with a cancelled context should return an error")
+	}
+	if _, err := GetCacheWithContext(ctx, "cache/verify-image/cancelled"); err == nil {
+		t.Error("GetCacheWithContext() with a cancelled context should return an error")
+	}
+}
+
+func TestCacheMissReturnsError(t *testing.T) {
+	if _, err := GetCacheWithContext(context.Background(), "cache/verify-image/never-set"); err == nil {
+		t.Error("GetCacheWithContext() for an unset key should return an error")
+	}
+}
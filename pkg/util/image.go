@@ -0,0 +1,159 @@
+//
+// Copyright 2020 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package util
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+)
+
+// Image wraps the registry descriptor for a single pulled image reference, letting callers
+// tell an OCI index / Docker manifest list apart from a plain image (IsImageIndex) before
+// deciding how to read its contents.
+type Image struct {
+	desc *remote.Descriptor
+}
+
+// PullImage is kept for callers that do not need cancellation or custom registry
+// credentials; it delegates to PullImageWithContext using context.Background() and the
+// default keychain.
+func PullImage(imageRef string) (*Image, error) {
+	return PullImageWithContext(context.Background(), imageRef, nil)
+}
+
+// PullImageWithContext pulls imageRef's manifest (without necessarily fetching every layer
+// yet), authenticating with keychain when set, falling back to authn.DefaultKeychain
+// otherwise.
+func PullImageWithContext(ctx context.Context, imageRef string, keychain authn.Keychain) (*Image, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse image reference")
+	}
+	if keychain == nil {
+		keychain = authn.DefaultKeychain
+	}
+	desc, err := remote.Get(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch image descriptor")
+	}
+	return &Image{desc: desc}, nil
+}
+
+// IsImageIndex reports whether image resolves to an OCI image index / Docker manifest list
+// rather than a single-platform image.
+func IsImageIndex(image *Image) bool {
+	return image != nil && image.desc.MediaType.IsIndex()
+}
+
+// ImageIndexChild describes one platform-specific manifest referenced by an OCI index /
+// Docker manifest list.
+type ImageIndexChild struct {
+	Digest  string
+	OS      string
+	Arch    string
+	Variant string
+}
+
+// ListImageIndexChildren is kept for callers that do not need cancellation or custom
+// registry credentials; it delegates to ListImageIndexChildrenWithContext using
+// context.Background() and the default keychain.
+func ListImageIndexChildren(imageRef string) ([]ImageIndexChild, error) {
+	return ListImageIndexChildrenWithContext(context.Background(), imageRef, nil)
+}
+
+// ListImageIndexChildrenWithContext returns every platform-specific manifest referenced by
+// the OCI index / Docker manifest list at imageRef, as a fully qualified "repo@digest"
+// reference plus its platform descriptor, authenticating with keychain when set (falling
+// back to authn.DefaultKeychain otherwise, mirroring PullImageWithContext). imageRef itself
+// must resolve to an index; check with IsImageIndex first.
+func ListImageIndexChildrenWithContext(ctx context.Context, imageRef string, keychain authn.Keychain) ([]ImageIndexChild, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse image reference")
+	}
+	if keychain == nil {
+		keychain = authn.DefaultKeychain
+	}
+	desc, err := remote.Get(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch image descriptor")
+	}
+	idx, err := desc.ImageIndex()
+	if err != nil {
+		return nil, errors.Wrap(err, "reference does not resolve to an image index")
+	}
+	manifest, err := idx.IndexManifest()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read index manifest")
+	}
+
+	repoName := ref.Context().Name()
+	children := make([]ImageIndexChild, 0, len(manifest.Manifests))
+	for _, m := range manifest.Manifests {
+		child := ImageIndexChild{Digest: repoName + "@" + m.Digest.String()}
+		if m.Platform != nil {
+			child.OS = m.Platform.OS
+			child.Arch = m.Platform.Architecture
+			child.Variant = m.Platform.Variant
+		}
+		children = append(children, child)
+	}
+	return children, nil
+}
+
+// GenerateConcatYAMLsFromImage reads every layer of image's filesystem, extracts every
+// .yaml/.yml file found in any of them, and concatenates them into a single multi-document
+// YAML blob.
+func GenerateConcatYAMLsFromImage(image *Image) ([]byte, error) {
+	img, err := image.desc.Image()
+	if err != nil {
+		return nil, errors.Wrap(err, "reference does not resolve to a single-platform image")
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read image layers")
+	}
+
+	yamls := [][]byte{}
+	for _, layer := range layers {
+		layerYAMLs, err := yamlsInLayer(layer)
+		if err != nil {
+			return nil, err
+		}
+		yamls = append(yamls, layerYAMLs...)
+	}
+	return ConcatenateYAMLs(yamls), nil
+}
+
+func yamlsInLayer(layer v1.Layer) ([][]byte, error) {
+	rc, err := layer.Uncompressed()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read image layer")
+	}
+	defer rc.Close()
+	tarBytes, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read image layer contents")
+	}
+	return GetYAMLsInArtifact(tarBytes)
+}
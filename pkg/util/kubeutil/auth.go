@@ -0,0 +1,84 @@
+//
+// Copyright 2020 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kubeutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	k8smnfutil "github.com/sigstore/k8s-manifest-sigstore/pkg/util"
+)
+
+// dockerConfigJSONKey is the well-known data key of a kubernetes.io/dockerconfigjson
+// image-pull Secret.
+const dockerConfigJSONKey = ".dockerconfigjson"
+
+// objectRefPrefix is the scheme used by "k8s://Kind/[NAMESPACE]/[NAME]" object references.
+const objectRefPrefix = "k8s://"
+
+// GetKeychainFromImagePullSecretWithContext resolves secretRef (a k8s object reference of
+// the form "k8s://Secret/[NAMESPACE]/[NAME]") to an authn.Keychain backed by its
+// .dockerconfigjson data, for pulling images and signatures from a registry that requires
+// the credentials configured on that Secret.
+func GetKeychainFromImagePullSecretWithContext(ctx context.Context, secretRef string) (authn.Keychain, error) {
+	kind, ns, name, err := parseObjectRef(secretRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse an image pull secret reference")
+	}
+	if kind != "Secret" && kind != "secrets" {
+		return nil, errors.Errorf("image pull secret reference must be \"k8s://Secret/[NAMESPACE]/[NAME]\", but got %s", secretRef)
+	}
+
+	obj, err := GetResourceWithContext(ctx, "", kind, ns, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get an image pull secret")
+	}
+
+	objBytes, err := json.Marshal(obj.Object)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal an image pull secret")
+	}
+	var secret corev1.Secret
+	if err := json.Unmarshal(objBytes, &secret); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal an image pull secret")
+	}
+
+	configJSON, ok := secret.Data[dockerConfigJSONKey]
+	if !ok {
+		return nil, errors.Errorf("secret %s/%s has no %s entry", ns, name, dockerConfigJSONKey)
+	}
+	return k8smnfutil.KeychainFromDockerConfigJSON(configJSON)
+}
+
+// parseObjectRef splits a "k8s://Kind/[NAMESPACE]/[NAME]" reference into its kind,
+// namespace and name.
+func parseObjectRef(objRef string) (kind, namespace, name string, err error) {
+	if !strings.HasPrefix(objRef, objectRefPrefix) {
+		return "", "", "", fmt.Errorf("object reference must start with %q, but got %s", objectRefPrefix, objRef)
+	}
+	parts := strings.Split(strings.TrimPrefix(objRef, objectRefPrefix), "/")
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("object reference must be \"k8s://Kind/[NAMESPACE]/[NAME]\", but got %s", objRef)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
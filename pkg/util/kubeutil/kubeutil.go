@@ -0,0 +1,121 @@
+//
+// Copyright 2020 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package kubeutil wraps the in-cluster/kubeconfig-based Kubernetes client used to resolve
+// "k8s://Kind/[NAMESPACE]/[NAME]" object references into live resources.
+package kubeutil
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// GetResource is kept for callers that do not need cancellation; it delegates to
+// GetResourceWithContext using context.Background().
+func GetResource(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	return GetResourceWithContext(context.Background(), apiVersion, kind, namespace, name)
+}
+
+// GetResourceWithContext fetches the resource identified by apiVersion/kind/namespace/name
+// from the cluster the current kubeconfig (or in-cluster config) points at, resolving
+// kind to its REST resource via the cluster's discovery API.
+func GetResourceWithContext(ctx context.Context, apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	gvr, err := resolveGVR(apiVersion, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	dyClient, err := dynamicClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build a Kubernetes client")
+	}
+
+	var resourceClient dynamic.ResourceInterface = dyClient.Resource(gvr)
+	if namespace != "" {
+		resourceClient = dyClient.Resource(gvr).Namespace(namespace)
+	}
+
+	obj, err := resourceClient.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %s %s/%s", kind, namespace, name)
+	}
+	return obj, nil
+}
+
+// resolveGVR maps a (possibly version-less) apiVersion/kind pair to the GroupVersionResource
+// the dynamic client needs, using the cluster's discovery API to find the matching REST
+// mapping.
+func resolveGVR(apiVersion, kind string) (schema.GroupVersionResource, error) {
+	var gv schema.GroupVersion
+	var err error
+	if apiVersion != "" {
+		gv, err = schema.ParseGroupVersion(apiVersion)
+		if err != nil {
+			return schema.GroupVersionResource{}, errors.Wrap(err, "failed to parse apiVersion")
+		}
+	}
+	gvk := gv.WithKind(kind)
+
+	dc, err := discoveryClient()
+	if err != nil {
+		return schema.GroupVersionResource{}, errors.Wrap(err, "failed to build a discovery client")
+	}
+	groupResources, err := restmapper.GetAPIGroupResources(dc)
+	if err != nil {
+		return schema.GroupVersionResource{}, errors.Wrap(err, "failed to fetch API group resources")
+	}
+	mapper := restmapper.NewDiscoveryRESTMapper(groupResources)
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return schema.GroupVersionResource{}, errors.Wrapf(err, "failed to find a REST mapping for kind %s", kind)
+	}
+	return mapping.Resource, nil
+}
+
+func restConfig() (*rest.Config, error) {
+	if cfg, err := rest.InClusterConfig(); err == nil {
+		return cfg, nil
+	}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		clientcmd.NewDefaultClientConfigLoadingRules(),
+		&clientcmd.ConfigOverrides{},
+	).ClientConfig()
+}
+
+func discoveryClient() (discovery.DiscoveryInterface, error) {
+	cfg, err := restConfig()
+	if err != nil {
+		return nil, err
+	}
+	return discovery.NewDiscoveryClientForConfig(cfg)
+}
+
+func dynamicClient() (dynamic.Interface, error) {
+	cfg, err := restConfig()
+	if err != nil {
+		return nil, err
+	}
+	return dynamic.NewForConfig(cfg)
+}
@@ -0,0 +1,168 @@
+//
+// Copyright 2020 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package util
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// yamlSeparator is the document boundary used both when concatenating YAMLs together and
+// when splitting a concatenated blob back apart.
+const yamlSeparator = "\n---\n"
+
+// GetAnnotationsInYAML returns the annotations of the single Kubernetes object encoded in
+// objYAMLBytes, or an empty map if objYAMLBytes does not parse.
+func GetAnnotationsInYAML(objYAMLBytes []byte) map[string]string {
+	var obj unstructured.Unstructured
+	if err := yaml.Unmarshal(objYAMLBytes, &obj.Object); err != nil {
+		return map[string]string{}
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		return map[string]string{}
+	}
+	return annotations
+}
+
+// SplitCommaSeparatedString splits s on commas and trims surrounding whitespace from each
+// element, dropping any that are empty. A blank s is the one exception: it returns a single
+// empty-string element, matching how callers use it to mean "no configured value". Not
+// dropping interior empty elements would turn a stray comma (e.g. "a.pub,,b.pub") into a
+// real "" entry, which some callers (e.g. pubkey lists) treat as "switch to keyless
+// verification" instead of as a typo.
+func SplitCommaSeparatedString(s string) []string {
+	if s == "" {
+		return []string{""}
+	}
+	parts := strings.Split(s, ",")
+	result := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if trimmed := strings.TrimSpace(p); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+// ConcatenateYAMLs joins yamls into a single multi-document YAML blob, inserting a "---"
+// document separator between each one so downstream parsing (FindManifestYAML,
+// SplitConcatYAMLs) can tell where one document ends and the next begins regardless of
+// whether an individual document already ends in a newline.
+func ConcatenateYAMLs(yamls [][]byte) []byte {
+	nonEmpty := make([][]byte, 0, len(yamls))
+	for _, y := range yamls {
+		if len(bytes.TrimSpace(y)) == 0 {
+			continue
+		}
+		nonEmpty = append(nonEmpty, bytes.TrimRight(y, "\n"))
+	}
+	return bytes.Join(nonEmpty, []byte(yamlSeparator))
+}
+
+// SplitConcatYAMLs splits a YAML blob produced by ConcatenateYAMLs (or any "---"-delimited
+// multi-document YAML) back into its individual documents.
+func SplitConcatYAMLs(concatYAMLBytes []byte) [][]byte {
+	docs := [][]byte{}
+	for _, doc := range strings.Split(string(concatYAMLBytes), "---") {
+		trimmed := strings.TrimSpace(doc)
+		if trimmed == "" {
+			continue
+		}
+		docs = append(docs, []byte(trimmed))
+	}
+	return docs
+}
+
+// FindManifestYAML searches concatYAMLBytes for documents that describe the same
+// apiVersion/kind/namespace/name as objYAMLBytes and returns up to maxResourceManifestNum
+// matches (no limit when maxResourceManifestNum is nil). No other fields are compared, and
+// ignoreFields is not used.
+func FindManifestYAML(concatYAMLBytes, objYAMLBytes []byte, maxResourceManifestNum *int, ignoreFields []string) (bool, [][]byte) {
+	var target unstructured.Unstructured
+	if err := yaml.Unmarshal(objYAMLBytes, &target.Object); err != nil {
+		return false, nil
+	}
+
+	matches := [][]byte{}
+	for _, doc := range SplitConcatYAMLs(concatYAMLBytes) {
+		var candidate unstructured.Unstructured
+		if err := yaml.Unmarshal(doc, &candidate.Object); err != nil {
+			continue
+		}
+		if candidate.GetAPIVersion() != target.GetAPIVersion() || candidate.GetKind() != target.GetKind() {
+			continue
+		}
+		if candidate.GetName() != target.GetName() || candidate.GetNamespace() != target.GetNamespace() {
+			continue
+		}
+		matches = append(matches, doc)
+		if maxResourceManifestNum != nil && len(matches) >= *maxResourceManifestNum {
+			break
+		}
+	}
+	return len(matches) > 0, matches
+}
+
+// GzipDecompress decompresses gzipBytes (a gzip-compressed tarball), returning the raw tar
+// bytes, or nil if gzipBytes is not valid gzip data.
+func GzipDecompress(gzipBytes []byte) []byte {
+	reader, err := gzip.NewReader(bytes.NewReader(gzipBytes))
+	if err != nil {
+		return nil
+	}
+	defer reader.Close()
+	out, err := io.ReadAll(reader)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+// GetYAMLsInArtifact extracts every .yaml/.yml file from tarBytes (an uncompressed tar
+// archive, typically produced by GzipDecompress) and returns their contents.
+func GetYAMLsInArtifact(tarBytes []byte) ([][]byte, error) {
+	yamls := [][]byte{}
+	tr := tar.NewReader(bytes.NewReader(tarBytes))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if !strings.HasSuffix(hdr.Name, ".yaml") && !strings.HasSuffix(hdr.Name, ".yml") {
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		yamls = append(yamls, content)
+	}
+	return yamls, nil
+}
@@ -0,0 +1,65 @@
+//
+// Copyright 2020 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitCommaSeparatedString(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"blank string yields a single empty element", "", []string{""}},
+		{"single value", "a.pub", []string{"a.pub"}},
+		{"trims whitespace", " a.pub , b.pub ", []string{"a.pub", "b.pub"}},
+		{"a stray comma does not inject an empty entry", "a.pub,,b.pub", []string{"a.pub", "b.pub"}},
+		{"an all-commas string yields no entries", ",,", []string{}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := SplitCommaSeparatedString(c.in); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("SplitCommaSeparatedString(%q) = %#v, want %#v", c.in, got, c.want)
+			}
+		})
+	}
+}
+
+func TestConcatenateAndSplitYAMLs(t *testing.T) {
+	// Deliberately omit the trailing newline on the first document: a naive append-based
+	// join would run its last line into the second document's first line.
+	docs := [][]byte{
+		[]byte("kind: Pod\nmetadata:\n  name: a"),
+		[]byte("kind: Pod\nmetadata:\n  name: b\n"),
+	}
+
+	concatenated := ConcatenateYAMLs(docs)
+	split := SplitConcatYAMLs(concatenated)
+
+	if len(split) != 2 {
+		t.Fatalf("expected 2 documents after split, got %d: %q", len(split), concatenated)
+	}
+	if string(split[0]) != "kind: Pod\nmetadata:\n  name: a" {
+		t.Errorf("first document corrupted: %q", split[0])
+	}
+	if string(split[1]) != "kind: Pod\nmetadata:\n  name: b" {
+		t.Errorf("second document corrupted: %q", split[1])
+	}
+}